@@ -1,7 +1,9 @@
 package twilio
 
 import (
+	"fmt"
 	"net/url"
+	"time"
 
 	"golang.org/x/net/context"
 )
@@ -75,6 +77,31 @@ func (a *AccountService) GetPage(ctx context.Context, data url.Values) (*Account
 // AccountPageIterator lets you retrieve consecutive AccountPages.
 type AccountPageIterator struct {
 	p *PageIterator
+	// start and end are the bounds of a GetAccountsInRange iterator, or the
+	// zero time otherwise.
+	start time.Time
+	end   time.Time
+}
+
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *AccountPageIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *AccountPageIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (c *AccountPageIterator) Cursor() Cursor {
+	cur := c.p.Cursor()
+	cur.Start = c.start
+	cur.End = c.end
+	return cur
 }
 
 // GetPageIterator returns a AccountPageIterator with the given page
@@ -87,9 +114,29 @@ func (c *AccountService) GetPageIterator(data url.Values) *AccountPageIterator {
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the
+// Cursor of an earlier iterator.
+func (c *AccountService) ResumePageIterator(cur Cursor) *AccountPageIterator {
+	iter := ResumePageIterator(c.client, accountPathPart+".json", cur)
+	return &AccountPageIterator{
+		p:     iter,
+		start: cur.Start,
+		end:   cur.End,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
-// NoMoreResults is returned.
+// NoMoreResults is returned. If the iterator was returned by
+// GetAccountsInRange, Next may fetch several pages to find one with results in
+// the range.
 func (c *AccountPageIterator) Next(ctx context.Context) (*AccountPage, error) {
+	if !c.end.IsZero() {
+		page, err := nextPageInRange(ctx, c.p, c.start, c.end, false, func() datedPage { return new(AccountPage) })
+		if err != nil {
+			return nil, err
+		}
+		return page.(*AccountPage), nil
+	}
 	cp := new(AccountPage)
 	err := c.p.Next(ctx, cp)
 	if err != nil {
@@ -98,3 +145,89 @@ func (c *AccountPageIterator) Next(ctx context.Context) (*AccountPage, error) {
 	c.p.SetNextPageURI(cp.NextPageURI)
 	return cp, nil
 }
+
+// GetAccountsInRange returns an iterator over the accounts created in the range
+// [start, end), optionally further filtered by data. start and end may have any
+// precision; accounts outside the range are removed from each page.
+// GetAccountsInRange panics if start is after end. If you have an end, but
+// don't want to specify a start, use twilio.Epoch for start. If you have a
+// start, but don't want to specify an end, use twilio.HeatDeath for end. The
+// API can't filter accounts by date, and doesn't document the order they're
+// returned in, so this pages through every account, filtering as it goes.
+// Returned pages have at most PageSize results, but may have fewer.
+func (c *AccountService) GetAccountsInRange(start time.Time, end time.Time, data url.Values) *AccountPageIterator {
+	if start.After(end) {
+		panic("start date is after end date")
+	}
+	d := url.Values{}
+	for k, v := range data {
+		d[k] = v
+	}
+	d.Del("Page") // just in case
+	return &AccountPageIterator{
+		p:     NewPageIterator(c.client, d, accountPathPart+".json"),
+		start: start,
+		end:   end,
+	}
+}
+
+func (p *AccountPage) dates() ([]time.Time, error) {
+	times := make([]time.Time, len(p.Accounts))
+	for i, account := range p.Accounts {
+		if !account.DateCreated.Valid {
+			return nil, fmt.Errorf("Couldn't verify the date of account: %#v", account)
+		}
+		times[i] = account.DateCreated.Time
+	}
+	return times, nil
+}
+
+func (p *AccountPage) removeIndexes(indexes []int) {
+	// reverse order so we don't delete the wrong index
+	for i := len(indexes) - 1; i >= 0; i-- {
+		p.Accounts = append(p.Accounts[:indexes[i]], p.Accounts[indexes[i]+1:]...)
+	}
+}
+
+// AccountIterator yields Accounts one at a time. Call Next to advance the
+// iterator, and Value to get the current Account.
+type AccountIterator struct {
+	*ItemIterator
+}
+
+// NewAccountIterator returns a AccountIterator that yields each Account in the
+// pages returned by p.
+func NewAccountIterator(p *AccountPageIterator) *AccountIterator {
+	return &AccountIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.Accounts))
+		for i := range page.Accounts {
+			items[i] = page.Accounts[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current Account, or nil if Next hasn't been called or
+// returned false.
+func (a *AccountIterator) Value() *Account {
+	v, _ := a.ItemIterator.Value().(*Account)
+	return v
+}
+
+// GetIterator returns an iterator over the Accounts matching the filters in
+// data, fetching new pages as needed.
+func (c *AccountService) GetIterator(data url.Values) *AccountIterator {
+	return NewAccountIterator(c.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (c *AccountService) ResumeIterator(cur Cursor) *AccountIterator {
+	iter := NewAccountIterator(c.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
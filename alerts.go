@@ -60,7 +60,8 @@ func (a *AlertService) GetPage(ctx context.Context, data url.Values) (*AlertPage
 	return a.GetPageIterator(data).Next(ctx)
 }
 
-// AlertPageIterator lets you retrieve consecutive pages of resources.
+// AlertPageIterator lets you retrieve consecutive pages of resources. The
+// iterators returned by AlertService also implement Prefetcher.
 type AlertPageIterator interface {
 	// Next returns the next page of resources. If there are no more resources,
 	// NoMoreResults is returned.
@@ -71,6 +72,24 @@ type alertPageIterator struct {
 	p *PageIterator
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (a *alertPageIterator) Prefetch(depth int) {
+	a.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (a *alertPageIterator) Stop() {
+	a.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (a *alertPageIterator) Cursor() Cursor {
+	return a.p.Cursor()
+}
+
 // GetAlertsInRange gets an Iterator containing conferences in the range
 // [start, end), optionally further filtered by data. GetAlertsInRange
 // panics if start is not before end. Any date filters provided in data will
@@ -140,51 +159,64 @@ type alertDateIterator struct {
 	end   time.Time
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (a *alertDateIterator) Prefetch(depth int) {
+	a.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (a *alertDateIterator) Stop() {
+	a.p.Stop()
+}
+
+// Cursor returns the position of the iterator, including the range bounds.
+func (a *alertDateIterator) Cursor() Cursor {
+	cur := a.p.Cursor()
+	cur.Start = a.start
+	cur.End = a.end
+	return cur
+}
+
 // Next returns the next page of resources. We may need to fetch multiple
 // pages from the Twilio API before we find one in the right date range, so
 // latency may be higher than usual. If page is non-nil, it contains at least
 // one result.
 func (a *alertDateIterator) Next(ctx context.Context) (*AlertPage, error) {
-	var page *AlertPage
-	for {
-		// just wipe it clean every time to avoid remnants hanging around
-		page = new(AlertPage)
-		if err := a.p.Next(ctx, page); err != nil {
-			return nil, err
-		}
-		if len(page.Alerts) == 0 {
-			return nil, NoMoreResults
-		}
-		times := make([]time.Time, len(page.Alerts), len(page.Alerts))
-		for i, alert := range page.Alerts {
-			if !alert.DateCreated.Valid {
-				// we really should not ever hit this case but if we can't parse
-				// a date, better to give you back an error than to give you back
-				// a list of alerts that may or may not be in the time range
-				return nil, fmt.Errorf("Couldn't verify the date of alert: %#v", alert)
-			}
-			times[i] = alert.DateCreated.Time
-		}
-		if containsResultsInRange(a.start, a.end, times) {
-			indexesToDelete := indexesOutsideRange(a.start, a.end, times)
-			// reverse order so we don't delete the wrong index
-			for i := len(indexesToDelete) - 1; i >= 0; i-- {
-				index := indexesToDelete[i]
-				page.Alerts = append(page.Alerts[:index], page.Alerts[index+1:]...)
-			}
-			a.p.SetNextPageURI(page.Meta.NextPageURL)
-			return page, nil
-		}
-		if shouldContinuePaging(a.start, times) {
-			a.p.SetNextPageURI(page.Meta.NextPageURL)
-			continue
-		} else {
-			// should not continue paging and no results in range, stop
-			return nil, NoMoreResults
+	page, err := nextPageInRange(ctx, a.p, a.start, a.end, true, func() datedPage { return new(AlertPage) })
+	if err != nil {
+		return nil, err
+	}
+	return page.(*AlertPage), nil
+}
+
+func (p *AlertPage) dates() ([]time.Time, error) {
+	times := make([]time.Time, len(p.Alerts))
+	for i, alert := range p.Alerts {
+		if !alert.DateCreated.Valid {
+			// we really should not ever hit this case but if we can't parse
+			// a date, better to give you back an error than to give you back
+			// a list of alerts that may or may not be in the time range
+			return nil, fmt.Errorf("Couldn't verify the date of alert: %#v", alert)
 		}
+		times[i] = alert.DateCreated.Time
+	}
+	return times, nil
+}
+
+func (p *AlertPage) removeIndexes(indexes []int) {
+	// reverse order so we don't delete the wrong index
+	for i := len(indexes) - 1; i >= 0; i-- {
+		p.Alerts = append(p.Alerts[:indexes[i]], p.Alerts[indexes[i]+1:]...)
 	}
 }
 
+// AlertPage has no Page; the next page is in Meta.
+func (p *AlertPage) nextPageURI() types.NullString {
+	return p.Meta.NextPageURL
+}
+
 // GetPageIterator returns a AlertPageIterator with the given page
 // filters. Call iterator.Next() to get the first page of resources (and again
 // to retrieve subsequent pages).
@@ -195,6 +227,23 @@ func (a *AlertService) GetPageIterator(data url.Values) AlertPageIterator {
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator. If cur came from a GetAlertsInRange iterator, the
+// returned iterator only returns results in the same range.
+func (a *AlertService) ResumePageIterator(cur Cursor) AlertPageIterator {
+	iter := ResumePageIterator(a.client, alertPathPart, cur)
+	if cur.inRange() {
+		return &alertDateIterator{
+			start: cur.Start,
+			end:   cur.End,
+			p:     iter,
+		}
+	}
+	return &alertPageIterator{
+		p: iter,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
 // NoMoreResults is returned.
 func (a *alertPageIterator) Next(ctx context.Context) (*AlertPage, error) {
@@ -241,6 +290,9 @@ func (a *Alert) description() string {
 			if msg := vals.Get("Msg"); msg != "" {
 				return msg
 			}
+			if title := a.ErrorCode.Title(); title != "" {
+				return title
+			}
 			if a.MoreInfo != "" {
 				return fmt.Sprintf("Error %d: %s", a.ErrorCode, a.MoreInfo)
 			}
@@ -275,3 +327,72 @@ func (a *Alert) StatusCode() int {
 	}
 	return 0
 }
+
+// AlertIterator yields Alerts one at a time. Call Next to advance the iterator,
+// and Value to get the current Alert.
+type AlertIterator struct {
+	*ItemIterator
+}
+
+// NewAlertIterator returns a AlertIterator that yields each Alert in the pages
+// returned by p.
+func NewAlertIterator(p AlertPageIterator) *AlertIterator {
+	return &AlertIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.Alerts))
+		for i := range page.Alerts {
+			items[i] = page.Alerts[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current Alert, or nil if Next hasn't been called or
+// returned false.
+func (a *AlertIterator) Value() *Alert {
+	v, _ := a.ItemIterator.Value().(*Alert)
+	return v
+}
+
+// GetIterator returns an iterator over the Alerts matching the filters in
+// data, fetching new pages as needed.
+func (a *AlertService) GetIterator(data url.Values) *AlertIterator {
+	return NewAlertIterator(a.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (a *AlertService) ResumeIterator(cur Cursor) *AlertIterator {
+	iter := NewAlertIterator(a.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
+
+// Stream sends the alerts matching the filters in data to the returned channel,
+// fetching new pages as needed. If the caller doesn't keep up, Stream waits
+// for it before fetching more pages. The alerts channel is closed when there
+// are no more alerts, an error occurs, or ctx is cancelled; then the error
+// channel receives the error (or nil) and is closed. To stop early, cancel
+// ctx.
+func (a *AlertService) Stream(ctx context.Context, data url.Values) (<-chan *Alert, <-chan error) {
+	alerts := make(chan *Alert, streamBufferSize)
+	errs := make(chan error, 1)
+	iter := a.GetIterator(data)
+	go func() {
+		err := iter.stream(ctx, func(v interface{}) bool {
+			select {
+			case alerts <- v.(*Alert):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		close(alerts)
+		errs <- err
+		close(errs)
+	}()
+	return alerts, errs
+}
@@ -80,6 +80,24 @@ type ApplicationPageIterator struct {
 	p *PageIterator
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *ApplicationPageIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *ApplicationPageIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (c *ApplicationPageIterator) Cursor() Cursor {
+	return c.p.Cursor()
+}
+
 // GetPageIterator returns a ApplicationPageIterator with the given page
 // filters. Call iterator.Next() to get the first page of resources (and again
 // to retrieve subsequent pages).
@@ -90,6 +108,15 @@ func (c *ApplicationService) GetPageIterator(data url.Values) *ApplicationPageIt
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the
+// Cursor of an earlier iterator.
+func (c *ApplicationService) ResumePageIterator(cur Cursor) *ApplicationPageIterator {
+	iter := ResumePageIterator(c.client, applicationPathPart, cur)
+	return &ApplicationPageIterator{
+		p: iter,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
 // NoMoreResults is returned.
 func (c *ApplicationPageIterator) Next(ctx context.Context) (*ApplicationPage, error) {
@@ -101,3 +128,46 @@ func (c *ApplicationPageIterator) Next(ctx context.Context) (*ApplicationPage, e
 	c.p.SetNextPageURI(ap.NextPageURI)
 	return ap, nil
 }
+
+// ApplicationIterator yields Applications one at a time. Call Next to advance
+// the iterator, and Value to get the current Application.
+type ApplicationIterator struct {
+	*ItemIterator
+}
+
+// NewApplicationIterator returns a ApplicationIterator that yields each
+// Application in the pages returned by p.
+func NewApplicationIterator(p *ApplicationPageIterator) *ApplicationIterator {
+	return &ApplicationIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.Applications))
+		for i := range page.Applications {
+			items[i] = page.Applications[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current Application, or nil if Next hasn't been called or
+// returned false.
+func (a *ApplicationIterator) Value() *Application {
+	v, _ := a.ItemIterator.Value().(*Application)
+	return v
+}
+
+// GetIterator returns an iterator over the Applications matching the filters in
+// data, fetching new pages as needed.
+func (c *ApplicationService) GetIterator(data url.Values) *ApplicationIterator {
+	return NewApplicationIterator(c.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (c *ApplicationService) ResumeIterator(cur Cursor) *ApplicationIterator {
+	iter := NewApplicationIterator(c.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
@@ -0,0 +1,231 @@
+package twilio
+
+import (
+	"errors"
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// ErrDuplicateRecipient is the error in the BulkResult for a message to a
+// number that already appeared earlier in the batch.
+var ErrDuplicateRecipient = errors.New("twilio: Duplicate recipient, message not sent")
+
+// Defaults for BulkOptions.
+const (
+	DefaultBulkConcurrency = 10
+	DefaultBulkPerSecond   = 1
+)
+
+// BulkOptions configure SendBulk.
+type BulkOptions struct {
+	// Concurrency is the most messages that will be sent at once. Defaults to
+	// DefaultBulkConcurrency.
+	Concurrency int
+	// PerSecond is the most messages per second sent from each From number or
+	// Messaging Service. Twilio queues messages sent faster than a number
+	// can send them, so pacing them here keeps them from sitting in the
+	// queue, or failing when it's full. Defaults to DefaultBulkPerSecond, the
+	// rate for a US long code; short codes and Messaging Services with
+	// several numbers can go much faster.
+	PerSecond float64
+	// OnProgress, if set, is called after each message is sent or skipped,
+	// with the result for that message. Calls are never made concurrently.
+	OnProgress func(BulkProgress, *BulkResult)
+}
+
+// BulkProgress counts the messages SendBulk has handled so far.
+type BulkProgress struct {
+	Total   int
+	Sent    int
+	Failed  int
+	Skipped int
+}
+
+// BulkResult is the outcome of sending one message with SendBulk.
+type BulkResult struct {
+	// Index is the position of the message in the slice passed to SendBulk.
+	Index int
+	// To is the recipient in E.164 format, or the number as given if it
+	// couldn't be parsed.
+	To string
+	// Message is the created message, or nil if Err is not nil.
+	Message *Message
+	// Err is nil if the message was created. Otherwise it's a
+	// *ValidationError if the message was invalid, ErrDuplicateRecipient if
+	// it was skipped, an *Error from the API, or the Context's error if
+	// SendBulk was canceled before the message was sent.
+	Err error
+}
+
+// Sid returns the sid of the created Message, or the empty string if it
+// wasn't created.
+func (r *BulkResult) Sid() string {
+	if r.Message == nil {
+		return ""
+	}
+	return r.Message.Sid
+}
+
+// SendBulk validates and sends each of msgs, running up to
+// opts.Concurrency requests at a time and pacing the messages from each From
+// number or Messaging Service to opts.PerSecond. Recipients are normalized
+// with NewPhoneNumber; a message to a number that appeared earlier in msgs is
+// not sent. opts may be nil.
+//
+// SendBulk returns one BulkResult for each message, in the same order as
+// msgs. If ctx is canceled before every message is sent, the messages that
+// weren't sent have the Context error in their result, and SendBulk returns it
+// too; otherwise the error is nil, even if some messages failed, or ctx was
+// canceled after the last message was sent. Check each result's Err.
+func (m *MessageService) SendBulk(ctx context.Context, msgs []*MessageParams, opts *BulkOptions) ([]*BulkResult, error) {
+	if opts == nil {
+		opts = &BulkOptions{}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	perSecond := opts.PerSecond
+	if perSecond <= 0 {
+		perSecond = DefaultBulkPerSecond
+	}
+	s := &bulkSender{
+		results:    make([]*BulkResult, len(msgs)),
+		buckets:    make(map[string]*bucket),
+		perSecond:  perSecond,
+		onProgress: opts.OnProgress,
+	}
+	s.progress.Total = len(msgs)
+
+	type job struct {
+		i      int
+		sender string
+		p      *MessageParams
+	}
+	// Validate and dedupe up front, in order, so the first message to each
+	// number is the one that's sent.
+	var jobs []job
+	seen := make(map[string]bool)
+	for i, p := range msgs {
+		r := &BulkResult{Index: i}
+		if p == nil {
+			r.Err = &ValidationError{Field: "To", Code: CodeMessageToRequired, Message: "a To number is required"}
+			s.finish(r)
+			continue
+		}
+		r.To = p.To
+		data, err := p.Values()
+		if err != nil {
+			r.Err = err
+			s.finish(r)
+			continue
+		}
+		r.To = data.Get("To")
+		if seen[r.To] {
+			r.Err = ErrDuplicateRecipient
+			s.finish(r)
+			continue
+		}
+		seen[r.To] = true
+		sender := data.Get("MessagingServiceSid")
+		if sender == "" {
+			sender = data.Get("From")
+		}
+		s.results[i] = r
+		jobs = append(jobs, job{i: i, sender: sender, p: p})
+	}
+
+	ch := make(chan job)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(jobs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range ch {
+				r := s.results[j.i]
+				if err := s.bucket(j.sender).wait(ctx); err != nil {
+					r.Err = err
+					s.abandon(err)
+				} else {
+					r.Message, r.Err = m.CreateWithParams(ctx, j.p)
+					if r.Err != nil {
+						r.Message = nil
+					}
+				}
+				s.finish(r)
+			}
+		}()
+	}
+	for k, j := range jobs {
+		select {
+		case ch <- j:
+			continue
+		case <-ctx.Done():
+		}
+		s.abandon(ctx.Err())
+		for _, j := range jobs[k:] {
+			r := s.results[j.i]
+			r.Err = ctx.Err()
+			s.finish(r)
+		}
+		break
+	}
+	close(ch)
+	wg.Wait()
+	return s.results, s.canceled
+}
+
+// bulkSender holds the state shared by the SendBulk workers.
+type bulkSender struct {
+	perSecond  float64
+	onProgress func(BulkProgress, *BulkResult)
+
+	mu       sync.Mutex
+	results  []*BulkResult
+	buckets  map[string]*bucket
+	progress BulkProgress
+	// canceled is the Context error, if a message wasn't sent because the
+	// Context was canceled.
+	canceled error
+}
+
+// abandon records that a message wasn't sent because the Context was
+// canceled with err.
+func (s *bulkSender) abandon(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.canceled == nil {
+		s.canceled = err
+	}
+}
+
+// bucket returns the token bucket that paces messages from sender.
+func (s *bulkSender) bucket(sender string) *bucket {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[sender]
+	if !ok {
+		b = newBucket(s.perSecond, 1)
+		s.buckets[sender] = b
+	}
+	return b
+}
+
+// finish records r and reports progress.
+func (s *bulkSender) finish(r *BulkResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.results[r.Index] = r
+	switch {
+	case r.Err == nil:
+		s.progress.Sent++
+	case r.Err == ErrDuplicateRecipient:
+		s.progress.Skipped++
+	default:
+		s.progress.Failed++
+	}
+	if s.onProgress != nil {
+		s.onProgress(s.progress, r)
+	}
+}
@@ -0,0 +1,134 @@
+package twilio
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestSendBulk(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(sendMessageResponse)
+	defer s.Close()
+	msgs := []*MessageParams{
+		{To: "925 392 0364", From: from, Body: "hi"},
+		{To: "+19253920364", From: from, Body: "hi again"},
+		{To: "+14105551234", From: from, Body: ""},
+		{To: "+14105551234", MessagingServiceSid: "MG123", Body: "hi"},
+	}
+	var progress []BulkProgress
+	results, err := client.Messages.SendBulk(context.Background(), msgs, &BulkOptions{
+		PerSecond: 1000,
+		OnProgress: func(p BulkProgress, r *BulkResult) {
+			progress = append(progress, p)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.Index != i {
+			t.Errorf("result %d: got Index %d", i, r.Index)
+		}
+	}
+	if results[0].Err != nil || results[0].Sid() == "" || results[0].To != "+19253920364" {
+		t.Errorf("bad first result: %#v", results[0])
+	}
+	if results[1].Err != ErrDuplicateRecipient || results[1].Sid() != "" {
+		t.Errorf("expected duplicate, got %#v", results[1])
+	}
+	if _, ok := results[2].Err.(*ValidationError); !ok {
+		t.Errorf("expected a ValidationError, got %v", results[2].Err)
+	}
+	// the invalid message doesn't count as a recipient
+	if results[3].Err != nil {
+		t.Errorf("expected the last message to be sent, got %v", results[3].Err)
+	}
+	if len(s.URLs) != 2 {
+		t.Errorf("expected 2 requests, got %d", len(s.URLs))
+	}
+	if len(progress) != 4 {
+		t.Fatalf("expected 4 progress calls, got %d", len(progress))
+	}
+	want := BulkProgress{Total: 4, Sent: 2, Failed: 1, Skipped: 1}
+	if last := progress[3]; last != want {
+		t.Errorf("expected final progress %#v, got %#v", want, last)
+	}
+}
+
+func TestSendBulkPacing(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(sendMessageResponse)
+	defer s.Close()
+	msgs := []*MessageParams{
+		{To: "+14105551230", From: from, Body: "hi"},
+		{To: "+14105551231", From: from, Body: "hi"},
+		{To: "+14105551232", From: from, Body: "hi"},
+	}
+	start := time.Now()
+	_, err := client.Messages.SendBulk(context.Background(), msgs, &BulkOptions{PerSecond: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the first message goes right away, the next two 20ms apart.
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("expected messages to be paced, but sending took %v", elapsed)
+	}
+}
+
+func TestSendBulkCancel(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(sendMessageResponse)
+	defer s.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	msgs := []*MessageParams{
+		{To: "+14105551230", From: from, Body: "hi"},
+		{To: "+14105551231", From: from, Body: "hi"},
+	}
+	results, err := client.Messages.SendBulk(ctx, msgs, nil)
+	if err != context.Canceled {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+	for _, r := range results {
+		if r.Err != context.Canceled {
+			t.Errorf("expected result to be canceled, got %v", r.Err)
+		}
+	}
+	if len(s.URLs) != 0 {
+		t.Errorf("expected no requests, got %d", len(s.URLs))
+	}
+}
+
+func TestSendBulkCanceledAfterLastMessage(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(sendMessageResponse)
+	defer s.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	msgs := []*MessageParams{
+		{To: "+14105551230", From: from, Body: "hi"},
+		{To: "+14105551231", From: from, Body: "hi"},
+	}
+	opts := &BulkOptions{
+		PerSecond: 100,
+		OnProgress: func(p BulkProgress, r *BulkResult) {
+			if p.Sent == p.Total {
+				cancel()
+			}
+		},
+	}
+	results, err := client.Messages.SendBulk(ctx, msgs, opts)
+	if err != nil {
+		t.Errorf("expected no error once every message was sent, got %v", err)
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("expected result to succeed, got %v", r.Err)
+		}
+	}
+}
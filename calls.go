@@ -98,24 +98,46 @@ func (c *CallService) Update(ctx context.Context, sid string, data url.Values) (
 
 // Cancel an in-progress Call with the given sid. Cancel will not affect
 // in-progress Calls, only those in queued or ringing.
+//
+// Deprecated: Use CancelContext, which accepts a Context.
 func (c *CallService) Cancel(sid string) (*Call, error) {
+	return c.CancelContext(context.Background(), sid)
+}
+
+// CancelContext cancels the Call with the given sid. It will not affect
+// in-progress Calls, only those in queued or ringing.
+func (c *CallService) CancelContext(ctx context.Context, sid string) (*Call, error) {
 	data := url.Values{}
 	data.Set("Status", string(StatusCanceled))
-	return c.Update(context.Background(), sid, data)
+	return c.Update(ctx, sid, data)
 }
 
 // Hang up an in-progress call.
+//
+// Deprecated: Use HangupContext, which accepts a Context.
 func (c *CallService) Hangup(sid string) (*Call, error) {
+	return c.HangupContext(context.Background(), sid)
+}
+
+// HangupContext hangs up the in-progress Call with the given sid.
+func (c *CallService) HangupContext(ctx context.Context, sid string) (*Call, error) {
 	data := url.Values{}
 	data.Set("Status", string(StatusCompleted))
-	return c.Update(context.Background(), sid, data)
+	return c.Update(ctx, sid, data)
 }
 
 // Redirect the given call to the given URL.
+//
+// Deprecated: Use RedirectContext, which accepts a Context.
 func (c *CallService) Redirect(sid string, u *url.URL) (*Call, error) {
+	return c.RedirectContext(context.Background(), sid, u)
+}
+
+// RedirectContext redirects the Call with the given sid to the given URL.
+func (c *CallService) RedirectContext(ctx context.Context, sid string, u *url.URL) (*Call, error) {
 	data := url.Values{}
 	data.Set("Url", u.String())
-	return c.Update(context.Background(), sid, data)
+	return c.Update(ctx, sid, data)
 }
 
 // Initiate a new Call.
@@ -125,15 +147,35 @@ func (c *CallService) Create(ctx context.Context, data url.Values) (*Call, error
 	return call, err
 }
 
+// CreateWithParams validates p and makes a call with it. If p is not valid, a
+// *ValidationError is returned and no request is made.
+func (c *CallService) CreateWithParams(ctx context.Context, p *CallParams) (*Call, error) {
+	data, err := p.Values()
+	if err != nil {
+		return nil, err
+	}
+	return c.Create(ctx, data)
+}
+
 // MakeCall starts a new Call from the given phone number to the given phone
 // number, dialing the url when the call connects. MakeCall is a wrapper around
 // Create; if you need more configuration, call that function directly.
+//
+// Deprecated: Use MakeCallContext, which accepts a Context.
 func (c *CallService) MakeCall(from string, to string, u *url.URL) (*Call, error) {
+	return c.MakeCallContext(context.Background(), from, to, u)
+}
+
+// MakeCallContext starts a new Call from the given phone number to the given
+// phone number, dialing the url when the call connects. MakeCallContext is
+// a wrapper around Create; if you need more configuration, call that function
+// directly.
+func (c *CallService) MakeCallContext(ctx context.Context, from string, to string, u *url.URL) (*Call, error) {
 	data := url.Values{}
 	data.Set("From", from)
 	data.Set("To", to)
 	data.Set("Url", u.String())
-	return c.Create(context.Background(), data)
+	return c.Create(ctx, data)
 }
 
 func (c *CallService) GetPage(ctx context.Context, data url.Values) (*CallPage, error) {
@@ -211,52 +253,61 @@ type callDateIterator struct {
 	end   time.Time
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *callDateIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *callDateIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator, including the range bounds.
+func (c *callDateIterator) Cursor() Cursor {
+	cur := c.p.Cursor()
+	cur.Start = c.start
+	cur.End = c.end
+	return cur
+}
+
 // Next returns the next page of resources. We may need to fetch multiple
 // pages from the Twilio API before we find one in the right date range, so
 // latency may be higher than usual. If page is non-nil, it contains at least
 // one result.
 func (c *callDateIterator) Next(ctx context.Context) (*CallPage, error) {
-	var page *CallPage
-	for {
-		// just wipe it clean every time to avoid remnants hanging around
-		page = new(CallPage)
-		if err := c.p.Next(ctx, page); err != nil {
-			return nil, err
-		}
-		if len(page.Calls) == 0 {
-			return nil, NoMoreResults
-		}
-		times := make([]time.Time, len(page.Calls), len(page.Calls))
-		for i, call := range page.Calls {
-			if !call.DateCreated.Valid {
-				// we really should not ever hit this case but if we can't parse
-				// a date, better to give you back an error than to give you back
-				// a list of calls that may or may not be in the time range
-				return nil, fmt.Errorf("Couldn't verify the date of call: %#v", call)
-			}
-			times[i] = call.DateCreated.Time
-		}
-		if containsResultsInRange(c.start, c.end, times) {
-			indexesToDelete := indexesOutsideRange(c.start, c.end, times)
-			// reverse order so we don't delete the wrong index
-			for i := len(indexesToDelete) - 1; i >= 0; i-- {
-				index := indexesToDelete[i]
-				page.Calls = append(page.Calls[:index], page.Calls[index+1:]...)
-			}
-			c.p.SetNextPageURI(page.NextPageURI)
-			return page, nil
-		}
-		if shouldContinuePaging(c.start, times) {
-			c.p.SetNextPageURI(page.NextPageURI)
-			continue
-		} else {
-			// should not continue paging and no results in range, stop
-			return nil, NoMoreResults
+	page, err := nextPageInRange(ctx, c.p, c.start, c.end, true, func() datedPage { return new(CallPage) })
+	if err != nil {
+		return nil, err
+	}
+	return page.(*CallPage), nil
+}
+
+func (p *CallPage) dates() ([]time.Time, error) {
+	times := make([]time.Time, len(p.Calls))
+	for i, call := range p.Calls {
+		if !call.DateCreated.Valid {
+			// we really should not ever hit this case but if we can't parse
+			// a date, better to give you back an error than to give you back
+			// a list of calls that may or may not be in the time range
+			return nil, fmt.Errorf("Couldn't verify the date of call: %#v", call)
 		}
+		times[i] = call.DateCreated.Time
+	}
+	return times, nil
+}
+
+func (p *CallPage) removeIndexes(indexes []int) {
+	// reverse order so we don't delete the wrong index
+	for i := len(indexes) - 1; i >= 0; i-- {
+		p.Calls = append(p.Calls[:indexes[i]], p.Calls[indexes[i]+1:]...)
 	}
 }
 
-// CallPageIterator lets you retrieve consecutive pages of resources.
+// CallPageIterator lets you retrieve consecutive pages of resources. The
+// iterators returned by CallService also implement Prefetcher.
 type CallPageIterator interface {
 	// Next returns the next page of resources. If there are no more resources,
 	// NoMoreResults is returned.
@@ -267,6 +318,24 @@ type callPageIterator struct {
 	p *PageIterator
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *callPageIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *callPageIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (c *callPageIterator) Cursor() Cursor {
+	return c.p.Cursor()
+}
+
 // GetPageIterator returns an iterator which can be used to retrieve pages.
 func (c *CallService) GetPageIterator(data url.Values) CallPageIterator {
 	iter := NewPageIterator(c.client, data, callsPathPart)
@@ -275,6 +344,23 @@ func (c *CallService) GetPageIterator(data url.Values) CallPageIterator {
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator. If cur came from a GetCallsInRange iterator, the
+// returned iterator only returns results in the same range.
+func (c *CallService) ResumePageIterator(cur Cursor) CallPageIterator {
+	iter := ResumePageIterator(c.client, callsPathPart, cur)
+	if cur.inRange() {
+		return &callDateIterator{
+			start: cur.Start,
+			end:   cur.End,
+			p:     iter,
+		}
+	}
+	return &callPageIterator{
+		p: iter,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
 // NoMoreResults is returned.
 func (c *callPageIterator) Next(ctx context.Context) (*CallPage, error) {
@@ -310,3 +396,72 @@ func (c *CallService) GetRecordingsIterator(callSid string, data url.Values) *Re
 	data.Set("CallSid", callSid)
 	return c.client.Recordings.GetPageIterator(data)
 }
+
+// CallIterator yields Calls one at a time. Call Next to advance the iterator,
+// and Value to get the current Call.
+type CallIterator struct {
+	*ItemIterator
+}
+
+// NewCallIterator returns a CallIterator that yields each Call in the pages
+// returned by p.
+func NewCallIterator(p CallPageIterator) *CallIterator {
+	return &CallIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.Calls))
+		for i := range page.Calls {
+			items[i] = page.Calls[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current Call, or nil if Next hasn't been called or returned
+// false.
+func (c *CallIterator) Value() *Call {
+	v, _ := c.ItemIterator.Value().(*Call)
+	return v
+}
+
+// GetIterator returns an iterator over the Calls matching the filters in
+// data, fetching new pages as needed.
+func (c *CallService) GetIterator(data url.Values) *CallIterator {
+	return NewCallIterator(c.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (c *CallService) ResumeIterator(cur Cursor) *CallIterator {
+	iter := NewCallIterator(c.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
+
+// Stream sends the calls matching the filters in data to the returned channel,
+// fetching new pages as needed. If the caller doesn't keep up, Stream waits
+// for it before fetching more pages. The calls channel is closed when there
+// are no more calls, an error occurs, or ctx is cancelled; then the error
+// channel receives the error (or nil) and is closed. To stop early, cancel
+// ctx.
+func (c *CallService) Stream(ctx context.Context, data url.Values) (<-chan *Call, <-chan error) {
+	calls := make(chan *Call, streamBufferSize)
+	errs := make(chan error, 1)
+	iter := c.GetIterator(data)
+	go func() {
+		err := iter.stream(ctx, func(v interface{}) bool {
+			select {
+			case calls <- v.(*Call):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		close(calls)
+		errs <- err
+		close(errs)
+	}()
+	return calls, errs
+}
@@ -113,3 +113,21 @@ func TestGetCallRange(t *testing.T) {
 		t.Errorf("wrong count, expected exactly 2 calls to Next(), got %d", count)
 	}
 }
+
+func TestMakeCallContext(t *testing.T) {
+	t.Parallel()
+	client, server := getServer(makeCallResponse)
+	defer server.Close()
+	u, _ := url.Parse("https://kev.inburke.com/zombo/zombocom.mp3")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Calls.MakeCallContext(ctx, from, to, u); err == nil {
+		t.Fatal("expected MakeCallContext with a canceled context to fail")
+	}
+	if _, err := client.Calls.HangupContext(context.Background(), "CA123"); err != nil {
+		t.Fatal(err)
+	}
+	if l := len(server.URLs); l != 1 {
+		t.Errorf("expected 1 request, got %d", l)
+	}
+}
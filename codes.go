@@ -37,14 +37,112 @@ func (c *Code) UnmarshalJSON(b []byte) error {
 	return c.convCode(i, err)
 }
 
+// Account errors
+const CodeAccountNotActive = 10001
+const CodeTrialAccountUnsupported = 10002
+const CodeIncomingCallRejectedInactiveAccount = 10003
+
+// HTTP errors when fetching TwiML or media from your server
+const CodeInvalidURLFormat = 11100
 const CodeHTTPRetrievalFailure = 11200
 const CodeHTTPConnectionFailure = 11205
 const CodeHTTPProtocolViolation = 11206
-const CodeReplyLimitExceeded = 14107
+const CodeHTTPBadHostName = 11210
+const CodeHTTPTooManyRedirects = 11215
+const CodeSSLHandshakeError = 11220
+const CodeTwiMLResponseTooLarge = 11750
+const CodeMediaExceedsSizeLimit = 11751
+
+// TwiML errors
 const CodeDocumentParseFailure = 12100
+const CodeSchemaValidationWarning = 12200
+const CodeInvalidContentType = 12300
+const CodeInternalFailure = 12400
+const CodeDialInvalidCallerID = 13214
+const CodeDialInvalidPhoneNumber = 13223
+const CodeDialUnsupportedNumber = 13224
 const CodeForbiddenPhoneNumber = 13225
 const CodeNoInternationalAuthorization = 13227
+const CodeGatherInvalidFinishOnKey = 13310
+const CodeGatherInvalidMethod = 13312
+const CodeGatherInvalidTimeout = 13313
+const CodeGatherInvalidNumDigits = 13314
+const CodeGatherInvalidNestedVerb = 13320
 const CodeSayInvalidText = 13520
+
+// Messaging TwiML errors
+const CodeMessageInvalidTo = 14101
+const CodeMessageInvalidFrom = 14102
+const CodeMessageInvalidBody = 14103
+const CodeMessageInvalidMethod = 14104
+const CodeMessageInvalidStatusCallback = 14105
+const CodeDocumentRetrievalLimit = 14106
+const CodeReplyLimitExceeded = 14107
+const CodeFromNotSMSCapable = 14108
+const CodeReplyMessageLimitExceeded = 14109
+const CodeInvalidVerbForReply = 14110
+
+// REST API errors
+const CodeUnknownParameters = 20001
+const CodeInvalidFriendlyName = 20002
+const CodePermissionDenied = 20003
+const CodeMethodNotAllowed = 20004
+const CodeAccountNotActiveAPI = 20005
+const CodeAccessDenied = 20006
+const CodeTestCredentials = 20008
+const CodeForbidden = 20403
+const CodeNotFound = 20404
+const CodeTooManyRequests = 20429
+const CodeInternalServerError = 20500
+const CodeServiceUnavailable = 20503
+
+// Call and phone number API errors
+const CodeNoToNumber = 21201
+const CodePremiumToNumber = 21202
+const CodeInternationalCallingDisabled = 21203
+const CodeInvalidURL = 21205
+const CodeFromNumberNotVerified = 21210
+const CodeInvalidToNumber = 21211
+const CodeInvalidFromNumber = 21212
+const CodeFromNumberRequired = 21213
+const CodeToNumberUnreachable = 21214
+const CodeGeoPermissionDenied = 21215
+const CodeCallToNumberNotAllowed = 21216
+const CodeInvalidPhoneNumberFormat = 21217
+const CodeInvalidApplicationSid = 21218
+const CodeToNumberNotVerified = 21219
+const CodeInvalidCallState = 21220
+const CodeInvalidPhoneNumber = 21401
+const CodeInvalidURLParameter = 21402
+const CodeInvalidMethod = 21403
+const CodeTrialInboundNumbersUnavailable = 21404
+const CodeSMSRegionNotEnabled = 21408
+const CodePhoneNumberInvalid = 21421
+const CodePhoneNumberUnavailable = 21422
+const CodePhoneNumberAlreadyValidated = 21450
+const CodeInvalidAreaCode = 21451
+const CodeNoNumbersInAreaCode = 21452
+
+// Messaging API errors
+const CodeNotSMSCapableInboundNumber = 21601
+const CodeMessageBodyRequired = 21602
+const CodeMessageFromRequired = 21603
+const CodeMessageToRequired = 21604
+const CodeInvalidMessageFrom = 21606
+const CodeTrialToNumberUnverified = 21608
+const CodeInvalidStatusCallback = 21609
+const CodeUnsubscribedRecipient = 21610
+const CodeFromQueueFull = 21611
+const CodeToNotReachableBySMS = 21612
+const CodeToNotMobileNumber = 21614
+const CodeMessageBodyTooLong = 21617
+const CodeBodyOrMediaRequired = 21619
+const CodeInvalidMediaURL = 21620
+const CodeFromNotMMSEnabled = 21621
+const CodeTooManyMediaFiles = 21623
+const CodeMessagingServiceNotFound = 21701
+
+// Message delivery errors
 const CodeQueueOverflow = 30001
 const CodeAccountSuspended = 30002
 const CodeUnreachable = 30003
@@ -55,3 +153,11 @@ const CodeCarrierViolation = 30007
 const CodeUnknownError = 30008
 const CodeMissingSegment = 30009
 const CodeMessagePriceExceedsMaxPrice = 30010
+
+// Twilio Client errors
+const CodeClientGenericError = 31000
+const CodeClientConnectionDeclined = 31002
+const CodeClientConnectionTimeout = 31003
+const CodeClientConnectionError = 31005
+const CodeClientUserAborted = 31008
+const CodeClientTransportError = 31009
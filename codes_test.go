@@ -0,0 +1,45 @@
+package twilio
+
+import "testing"
+
+func TestCommonCodes(t *testing.T) {
+	t.Parallel()
+	for code, info := range commonCodes {
+		if info.title == "" || info.description == "" || info.category == "" || info.logLevel == "" {
+			t.Errorf("code %d: missing information: %#v", code, info)
+		}
+	}
+	if title := Code(CodeInvalidToNumber).Title(); title != "Invalid 'To' phone number" {
+		t.Errorf("wrong title for 21211: %q", title)
+	}
+	if cat := Code(CodeLandline).Category(); cat != CategoryCarrier {
+		t.Errorf("expected 30006 to be a Carrier error, got %q", cat)
+	}
+	if lvl := Code(CodeSchemaValidationWarning).LogLevel(); lvl != LogLevelWarning {
+		t.Errorf("expected 12200 to be a warning, got %q", lvl)
+	}
+	if u := Code(CodeNotFound).URL(); u != "https://www.twilio.com/docs/errors/20404" {
+		t.Errorf("wrong URL for 20404: %q", u)
+	}
+	unknown := Code(99999)
+	if unknown.Known() || unknown.Title() != "" || unknown.Category() != "" {
+		t.Errorf("expected code 99999 to be unknown")
+	}
+	if s := unknown.Summary(); s != "Twilio error 99999 (https://www.twilio.com/docs/errors/99999)" {
+		t.Errorf("wrong summary for unknown code: %q", s)
+	}
+	if s := Code(CodeInvalidToNumber).Summary(); s != "Invalid 'To' phone number" {
+		t.Errorf("expected the summary of a known code to be its title, got %q", s)
+	}
+	if lvl := unknown.LogLevel(); lvl != LogLevelError {
+		t.Errorf("expected unknown code to log at error level, got %q", lvl)
+	}
+}
+
+func TestAlertDescriptionFromCatalog(t *testing.T) {
+	t.Parallel()
+	alert := &Alert{ErrorCode: CodeUnreachable, AlertText: "ErrorCode=30003&LogLevel=ERROR"}
+	if desc := alert.Description(); desc != "Unreachable destination handset" {
+		t.Errorf("wrong description: %q", desc)
+	}
+}
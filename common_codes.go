@@ -0,0 +1,208 @@
+package twilio
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Categories of Twilio error codes, as returned by Code.Category.
+const (
+	CategoryAccount      = "Account"
+	CategoryHTTP         = "HTTP"
+	CategoryTwiML        = "TwiML"
+	CategoryAPI          = "API"
+	CategoryVoice        = "Voice"
+	CategoryPhoneNumbers = "Phone Numbers"
+	CategoryMessaging    = "Messaging"
+	CategoryCarrier      = "Carrier"
+	CategoryClient       = "Client"
+)
+
+type codeInfo struct {
+	title       string
+	description string
+	category    string
+	logLevel    LogLevel
+}
+
+//go:generate go run internal/gencodes/main.go -o error_codes_gen.go
+
+// commonCodes describes the error codes callers are most likely to see,
+// maintained by hand from the Twilio error reference at
+// https://www.twilio.com/docs/api/errors/reference. On its own it doesn't cover
+// every code Twilio returns. Running go generate writes error_codes_gen.go from
+// Twilio's published list, which adds every other code to commonCodes when the
+// package is initialized. Codes that aren't described still work everywhere a
+// Code is used, but they don't have a Title, Description or Category; use
+// Summary or URL to describe them.
+var commonCodes = map[Code]codeInfo{
+	CodeAccountNotActive:                    {"Account is not active", "The account is suspended or closed, so the request can't be completed.", CategoryAccount, LogLevelError},
+	CodeTrialAccountUnsupported:             {"Trial account does not support this feature", "Upgrade the account to use this feature.", CategoryAccount, LogLevelError},
+	CodeIncomingCallRejectedInactiveAccount: {"Incoming call rejected due to inactive account", "A call to one of the account's numbers was rejected because the account isn't active.", CategoryAccount, LogLevelError},
+
+	CodeInvalidURLFormat:      {"Invalid URL format", "The URL configured for this request is not a valid URL.", CategoryHTTP, LogLevelError},
+	CodeHTTPRetrievalFailure:  {"HTTP retrieval failure", "Twilio tried to fetch a URL and got an error status code or no response.", CategoryHTTP, LogLevelError},
+	CodeHTTPConnectionFailure: {"HTTP connection failure", "Twilio couldn't connect to the server at the configured URL.", CategoryHTTP, LogLevelError},
+	CodeHTTPProtocolViolation: {"HTTP protocol violation", "The server at the configured URL returned an invalid HTTP response.", CategoryHTTP, LogLevelError},
+	CodeHTTPBadHostName:       {"HTTP bad host name", "The host name in the configured URL could not be resolved.", CategoryHTTP, LogLevelError},
+	CodeHTTPTooManyRedirects:  {"HTTP too many redirects", "The configured URL redirected too many times.", CategoryHTTP, LogLevelError},
+	CodeSSLHandshakeError:     {"SSL/TLS handshake error", "Twilio couldn't establish a secure connection to the configured URL.", CategoryHTTP, LogLevelError},
+	CodeTwiMLResponseTooLarge: {"TwiML response body too large", "The TwiML document returned by your server exceeded the maximum size.", CategoryHTTP, LogLevelError},
+	CodeMediaExceedsSizeLimit: {"Media exceeds messaging provider size limit", "A media file attached to the message is larger than the carrier accepts.", CategoryHTTP, LogLevelError},
+
+	CodeDocumentParseFailure:         {"Document parse failure", "The TwiML returned by your server is not valid XML.", CategoryTwiML, LogLevelError},
+	CodeSchemaValidationWarning:      {"Schema validation warning", "The TwiML returned by your server doesn't match the TwiML schema.", CategoryTwiML, LogLevelWarning},
+	CodeInvalidContentType:           {"Invalid Content-Type", "Your server returned a Content-Type Twilio can't process.", CategoryTwiML, LogLevelError},
+	CodeInternalFailure:              {"Internal failure", "Twilio hit an internal error while processing the TwiML.", CategoryTwiML, LogLevelError},
+	CodeDialInvalidCallerID:          {"Dial: Invalid callerId value", "The callerId attribute of the Dial verb is not a number you can call from.", CategoryTwiML, LogLevelError},
+	CodeDialInvalidPhoneNumber:       {"Dial: Invalid phone number format", "The number in the Dial verb is not a valid phone number.", CategoryTwiML, LogLevelError},
+	CodeDialUnsupportedNumber:        {"Dial: Invalid phone number", "Twilio does not support calling the number in the Dial verb, or the number is invalid.", CategoryTwiML, LogLevelError},
+	CodeForbiddenPhoneNumber:         {"Dial: Forbidden phone number", "Calls to this number are not allowed.", CategoryTwiML, LogLevelError},
+	CodeNoInternationalAuthorization: {"Dial: No international authorization", "The account isn't allowed to call the country of the dialed number.", CategoryTwiML, LogLevelError},
+	CodeGatherInvalidFinishOnKey:     {"Gather: Invalid finishOnKey value", "The finishOnKey attribute of the Gather verb must be a single digit, # or *.", CategoryTwiML, LogLevelWarning},
+	CodeGatherInvalidMethod:          {"Gather: Invalid method value", "The method attribute of the Gather verb must be GET or POST.", CategoryTwiML, LogLevelWarning},
+	CodeGatherInvalidTimeout:         {"Gather: Invalid timeout value", "The timeout attribute of the Gather verb must be a positive integer.", CategoryTwiML, LogLevelWarning},
+	CodeGatherInvalidNumDigits:       {"Gather: Invalid numDigits value", "The numDigits attribute of the Gather verb must be a positive integer.", CategoryTwiML, LogLevelWarning},
+	CodeGatherInvalidNestedVerb:      {"Gather: Invalid nested verb", "Only Say, Play and Pause can be nested inside a Gather verb.", CategoryTwiML, LogLevelWarning},
+	CodeSayInvalidText:               {"Say: Invalid text", "The text of the Say verb was empty or could not be parsed.", CategoryTwiML, LogLevelWarning},
+
+	CodeMessageInvalidTo:             {"Message: Invalid 'To' attribute", "The to attribute of the Message verb is not a valid phone number.", CategoryMessaging, LogLevelError},
+	CodeMessageInvalidFrom:           {"Message: Invalid 'From' attribute", "The from attribute of the Message verb is not a number you can send from.", CategoryMessaging, LogLevelError},
+	CodeMessageInvalidBody:           {"Message: Invalid body", "The body of the Message verb is empty or too long.", CategoryMessaging, LogLevelError},
+	CodeMessageInvalidMethod:         {"Message: Invalid method attribute", "The method attribute of the Message verb must be GET or POST.", CategoryMessaging, LogLevelWarning},
+	CodeMessageInvalidStatusCallback: {"Message: Invalid statusCallback attribute", "The statusCallback attribute of the Message verb is not a valid URL.", CategoryMessaging, LogLevelWarning},
+	CodeDocumentRetrievalLimit:       {"Document retrieval limit reached", "Twilio fetched too many TwiML documents while handling a single message.", CategoryMessaging, LogLevelError},
+	CodeReplyLimitExceeded:           {"SMS send rate limit exceeded", "Too many replies were sent between the same two numbers in a short period of time.", CategoryMessaging, LogLevelError},
+	CodeFromNotSMSCapable:            {"From phone number not SMS capable", "The number in the from attribute can't send SMS messages.", CategoryMessaging, LogLevelError},
+	CodeReplyMessageLimitExceeded:    {"SMS reply message limit exceeded", "The TwiML reply contained too many Message verbs.", CategoryMessaging, LogLevelError},
+	CodeInvalidVerbForReply:          {"Invalid verb for SMS reply", "The TwiML reply to a message contained a verb that can't be used with messages.", CategoryMessaging, LogLevelError},
+
+	CodeUnknownParameters:   {"Unknown parameters", "The request included parameters that the resource doesn't accept.", CategoryAPI, LogLevelError},
+	CodeInvalidFriendlyName: {"Invalid FriendlyName", "The FriendlyName is too long or contains invalid characters.", CategoryAPI, LogLevelError},
+	CodePermissionDenied:    {"Authentication failed", "The credentials used to make the request are invalid.", CategoryAPI, LogLevelError},
+	CodeMethodNotAllowed:    {"Method not allowed", "The resource does not support the HTTP method used.", CategoryAPI, LogLevelError},
+	CodeAccountNotActiveAPI: {"Account not active", "The account making the request is suspended or closed.", CategoryAPI, LogLevelError},
+	CodeAccessDenied:        {"Access denied", "The credentials used can't access this resource.", CategoryAPI, LogLevelError},
+	CodeTestCredentials:     {"Cannot access this resource with test credentials", "Test credentials only work with a small set of resources.", CategoryAPI, LogLevelError},
+	CodeForbidden:           {"Forbidden", "The account doesn't have permission to access this resource.", CategoryAPI, LogLevelError},
+	CodeNotFound:            {"Not found", "The requested resource does not exist.", CategoryAPI, LogLevelError},
+	CodeTooManyRequests:     {"Too many requests", "The account exceeded its concurrency or rate limit. Retry the request later.", CategoryAPI, LogLevelError},
+	CodeInternalServerError: {"Internal server error", "Twilio hit an internal error. Retry the request later.", CategoryAPI, LogLevelError},
+	CodeServiceUnavailable:  {"Service unavailable", "Twilio is temporarily unable to handle the request. Retry the request later.", CategoryAPI, LogLevelError},
+
+	CodeNoToNumber:                     {"No 'To' number is specified", "A request to create a Call must include a To number.", CategoryVoice, LogLevelError},
+	CodePremiumToNumber:                {"'To' number is a premium number", "Calls to premium rate numbers are not allowed.", CategoryVoice, LogLevelError},
+	CodeInternationalCallingDisabled:   {"International calling not enabled", "The account isn't allowed to call the country of the To number.", CategoryVoice, LogLevelError},
+	CodeInvalidURL:                     {"Invalid URL", "The Url parameter is not a valid URL.", CategoryVoice, LogLevelError},
+	CodeFromNumberNotVerified:          {"'From' phone number not verified", "Calls must come from a Twilio number or a verified Outgoing Caller ID.", CategoryVoice, LogLevelError},
+	CodeInvalidToNumber:                {"Invalid 'To' phone number", "The To number is not a valid phone number.", CategoryVoice, LogLevelError},
+	CodeInvalidFromNumber:              {"Invalid 'From' phone number", "The From number is not a valid phone number.", CategoryVoice, LogLevelError},
+	CodeFromNumberRequired:             {"'From' phone number is required", "A request to create a Call must include a From number.", CategoryVoice, LogLevelError},
+	CodeToNumberUnreachable:            {"'To' phone number cannot be reached", "The To number can't be reached by Twilio.", CategoryVoice, LogLevelError},
+	CodeGeoPermissionDenied:            {"Geo permission configuration is not permitting call", "The account's geographic permissions don't allow calls to the To number.", CategoryVoice, LogLevelError},
+	CodeCallToNumberNotAllowed:         {"Account not allowed to call phone number", "The account isn't permitted to call the To number.", CategoryVoice, LogLevelError},
+	CodeInvalidPhoneNumberFormat:       {"Phone number does not appear to be valid", "The phone number could not be parsed.", CategoryVoice, LogLevelError},
+	CodeInvalidApplicationSid:          {"Invalid ApplicationSid", "The ApplicationSid does not refer to an Application on this account.", CategoryVoice, LogLevelError},
+	CodeToNumberNotVerified:            {"'To' phone number not verified", "Trial accounts can only call verified numbers.", CategoryVoice, LogLevelError},
+	CodeInvalidCallState:               {"Invalid call state", "The Call can't be modified in its current state.", CategoryVoice, LogLevelError},
+	CodeInvalidPhoneNumber:             {"Invalid phone number", "The phone number is not valid.", CategoryPhoneNumbers, LogLevelError},
+	CodeInvalidURLParameter:            {"Invalid URL", "A URL parameter is not a valid URL.", CategoryPhoneNumbers, LogLevelError},
+	CodeInvalidMethod:                  {"Invalid method", "A Method parameter must be GET or POST.", CategoryPhoneNumbers, LogLevelError},
+	CodeTrialInboundNumbersUnavailable: {"Inbound phone numbers not available to trial accounts", "Upgrade the account to buy more phone numbers.", CategoryPhoneNumbers, LogLevelError},
+	CodeSMSRegionNotEnabled:            {"Permission to send an SMS has not been enabled for the region", "The account's geographic permissions don't allow messages to the To number.", CategoryMessaging, LogLevelError},
+	CodePhoneNumberInvalid:             {"PhoneNumber is invalid", "The PhoneNumber parameter is not a valid phone number.", CategoryPhoneNumbers, LogLevelError},
+	CodePhoneNumberUnavailable:         {"PhoneNumber is not available", "The phone number is not available to buy.", CategoryPhoneNumbers, LogLevelError},
+	CodePhoneNumberAlreadyValidated:    {"Phone number already validated on your account", "The number is already an Outgoing Caller ID.", CategoryPhoneNumbers, LogLevelError},
+	CodeInvalidAreaCode:                {"Invalid area code", "The AreaCode parameter is not a valid area code.", CategoryPhoneNumbers, LogLevelError},
+	CodeNoNumbersInAreaCode:            {"No phone numbers found in area code", "There are no phone numbers available in the requested area code.", CategoryPhoneNumbers, LogLevelError},
+
+	CodeNotSMSCapableInboundNumber: {"Phone number is not a valid SMS-capable inbound phone number", "The number can't receive SMS messages.", CategoryMessaging, LogLevelError},
+	CodeMessageBodyRequired:        {"Message body is required", "A request to send a Message must include a Body.", CategoryMessaging, LogLevelError},
+	CodeMessageFromRequired:        {"'From' phone number is required", "A request to send a Message must include a From number or a MessagingServiceSid.", CategoryMessaging, LogLevelError},
+	CodeMessageToRequired:          {"'To' phone number is required", "A request to send a Message must include a To number.", CategoryMessaging, LogLevelError},
+	CodeInvalidMessageFrom:         {"'From' phone number is not a valid message-capable Twilio phone number", "Messages must be sent from a Twilio number that can send messages.", CategoryMessaging, LogLevelError},
+	CodeTrialToNumberUnverified:    {"'To' phone number is not verified", "Trial accounts can only send messages to verified numbers.", CategoryMessaging, LogLevelError},
+	CodeInvalidStatusCallback:      {"Invalid StatusCallback", "The StatusCallback parameter is not a valid URL.", CategoryMessaging, LogLevelError},
+	CodeUnsubscribedRecipient:      {"Attempt to send to unsubscribed recipient", "The recipient replied STOP to this number and can't be messaged until they reply START.", CategoryMessaging, LogLevelError},
+	CodeFromQueueFull:              {"'From' number has exceeded the maximum number of queued messages", "Too many messages are waiting to be sent from this number.", CategoryMessaging, LogLevelError},
+	CodeToNotReachableBySMS:        {"'To' phone number is not currently reachable via SMS", "The To number can't receive SMS messages.", CategoryMessaging, LogLevelError},
+	CodeToNotMobileNumber:          {"'To' number is not a valid mobile number", "The To number is not a mobile number.", CategoryMessaging, LogLevelError},
+	CodeMessageBodyTooLong:         {"Message body exceeds the 1600 character limit", "The concatenated message body is too long to send.", CategoryMessaging, LogLevelError},
+	CodeBodyOrMediaRequired:        {"A message body or media URL must be specified", "A request to send a Message must include a Body or a MediaUrl.", CategoryMessaging, LogLevelError},
+	CodeInvalidMediaURL:            {"Invalid media URL", "One of the MediaUrl parameters is not a valid URL.", CategoryMessaging, LogLevelError},
+	CodeFromNotMMSEnabled:          {"'From' number has not been enabled for MMS", "The From number can't send media.", CategoryMessaging, LogLevelError},
+	CodeTooManyMediaFiles:          {"Number of media files exceeds allowed limit", "A Message can have at most 10 MediaUrls.", CategoryMessaging, LogLevelError},
+	CodeMessagingServiceNotFound:   {"Messaging Service does not exist", "The MessagingServiceSid does not refer to a Messaging Service on this account.", CategoryMessaging, LogLevelError},
+
+	CodeQueueOverflow:               {"Queue overflow", "Too many messages were queued for this number, and the message expired before it could be sent.", CategoryMessaging, LogLevelError},
+	CodeAccountSuspended:            {"Account suspended", "The account was suspended between the time the message was queued and the time it was sent.", CategoryMessaging, LogLevelError},
+	CodeUnreachable:                 {"Unreachable destination handset", "The destination handset is switched off or out of coverage.", CategoryCarrier, LogLevelError},
+	CodeMessageBlocked:              {"Message blocked", "The destination number is blocked from receiving this message.", CategoryMessaging, LogLevelError},
+	CodeUnknownDestination:          {"Unknown destination handset", "The destination number is not active, or is not known to the carrier.", CategoryCarrier, LogLevelError},
+	CodeLandline:                    {"Landline or unreachable carrier", "The destination number can't receive messages; it may be a landline.", CategoryCarrier, LogLevelError},
+	CodeCarrierViolation:            {"Carrier violation", "The carrier filtered the message, possibly as spam.", CategoryCarrier, LogLevelError},
+	CodeUnknownError:                {"Unknown error", "The carrier failed to deliver the message for an unknown reason.", CategoryCarrier, LogLevelError},
+	CodeMissingSegment:              {"Missing segment", "One or more segments of a long message failed to be delivered.", CategoryMessaging, LogLevelError},
+	CodeMessagePriceExceedsMaxPrice: {"Message price exceeds max price", "The price of the message is higher than the MaxPrice that was specified.", CategoryMessaging, LogLevelError},
+
+	CodeClientGenericError:       {"Generic error", "Twilio Client encountered an unexpected error.", CategoryClient, LogLevelError},
+	CodeClientConnectionDeclined: {"Connection declined", "The connection was declined, usually because of an invalid capability token.", CategoryClient, LogLevelError},
+	CodeClientConnectionTimeout:  {"Connection timeout", "The connection timed out before it could be established.", CategoryClient, LogLevelError},
+	CodeClientConnectionError:    {"Connection error", "The connection failed, usually because of a network problem.", CategoryClient, LogLevelError},
+	CodeClientUserAborted:        {"User aborted", "The user canceled the connection.", CategoryClient, LogLevelError},
+	CodeClientTransportError:     {"Transport error", "The connection to Twilio was lost.", CategoryClient, LogLevelError},
+}
+
+// Title returns a short title for the error code, like "Invalid 'To' phone
+// number", or the empty string if the code isn't one of the common codes this
+// package knows about (see Known). Use Summary for a description that's never
+// empty.
+func (c Code) Title() string {
+	return commonCodes[c].title
+}
+
+// Description returns a sentence describing what causes the error code, or the
+// empty string if the code is unknown.
+func (c Code) Description() string {
+	return commonCodes[c].description
+}
+
+// Category returns the category the error code belongs to, for example
+// CategoryTwiML or CategoryCarrier, or the empty string if the code is
+// unknown.
+func (c Code) Category() string {
+	return commonCodes[c].category
+}
+
+// LogLevel returns the level Twilio logs this error at. Unknown codes are
+// reported as LogLevelError.
+func (c Code) LogLevel() LogLevel {
+	if info, ok := commonCodes[c]; ok {
+		return info.logLevel
+	}
+	return LogLevelError
+}
+
+// URL returns the address of the Twilio documentation for the error code.
+func (c Code) URL() string {
+	return "https://www.twilio.com/docs/errors/" + strconv.Itoa(int(c))
+}
+
+// Summary returns the Title of the error code if it's known, or a message
+// pointing to the code's documentation if it isn't, for example "Twilio error
+// 12345 (https://www.twilio.com/docs/errors/12345)".
+func (c Code) Summary() string {
+	if title := c.Title(); title != "" {
+		return title
+	}
+	return fmt.Sprintf("Twilio error %d (%s)", c, c.URL())
+}
+
+// Known returns true if c is one of the common error codes this package
+// knows about, and its Title, Description and Category are available. Unless
+// error_codes_gen.go has been generated, most of the codes Twilio can return
+// are not known.
+func (c Code) Known() bool {
+	_, ok := commonCodes[c]
+	return ok
+}
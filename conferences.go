@@ -115,51 +115,61 @@ type conferenceDateIterator struct {
 	end   time.Time
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *conferenceDateIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *conferenceDateIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator, including the range bounds.
+func (c *conferenceDateIterator) Cursor() Cursor {
+	cur := c.p.Cursor()
+	cur.Start = c.start
+	cur.End = c.end
+	return cur
+}
+
 // Next returns the next page of resources. We may need to fetch multiple
 // pages from the Twilio API before we find one in the right date range, so
 // latency may be higher than usual. If page is non-nil, it contains at least
 // one result.
 func (c *conferenceDateIterator) Next(ctx context.Context) (*ConferencePage, error) {
-	var page *ConferencePage
-	for {
-		// just wipe it clean every time to avoid remnants hanging around
-		page = new(ConferencePage)
-		if err := c.p.Next(ctx, page); err != nil {
-			return nil, err
-		}
-		if len(page.Conferences) == 0 {
-			return nil, NoMoreResults
-		}
-		times := make([]time.Time, len(page.Conferences), len(page.Conferences))
-		for i, conference := range page.Conferences {
-			if !conference.DateCreated.Valid {
-				// we really should not ever hit this case but if we can't parse
-				// a date, better to give you back an error than to give you back
-				// a list of conferences that may or may not be in the time range
-				return nil, fmt.Errorf("Couldn't verify the date of conference: %#v", conference)
-			}
-			times[i] = conference.DateCreated.Time
-		}
-		if containsResultsInRange(c.start, c.end, times) {
-			indexesToDelete := indexesOutsideRange(c.start, c.end, times)
-			// reverse order so we don't delete the wrong index
-			for i := len(indexesToDelete) - 1; i >= 0; i-- {
-				index := indexesToDelete[i]
-				page.Conferences = append(page.Conferences[:index], page.Conferences[index+1:]...)
-			}
-			c.p.SetNextPageURI(page.NextPageURI)
-			return page, nil
-		}
-		if shouldContinuePaging(c.start, times) {
-			c.p.SetNextPageURI(page.NextPageURI)
-			continue
-		} else {
-			// should not continue paging and no results in range, stop
-			return nil, NoMoreResults
+	page, err := nextPageInRange(ctx, c.p, c.start, c.end, true, func() datedPage { return new(ConferencePage) })
+	if err != nil {
+		return nil, err
+	}
+	return page.(*ConferencePage), nil
+}
+
+func (p *ConferencePage) dates() ([]time.Time, error) {
+	times := make([]time.Time, len(p.Conferences))
+	for i, conference := range p.Conferences {
+		if !conference.DateCreated.Valid {
+			// we really should not ever hit this case but if we can't parse
+			// a date, better to give you back an error than to give you back
+			// a list of conferences that may or may not be in the time range
+			return nil, fmt.Errorf("Couldn't verify the date of conference: %#v", conference)
 		}
+		times[i] = conference.DateCreated.Time
+	}
+	return times, nil
+}
+
+func (p *ConferencePage) removeIndexes(indexes []int) {
+	// reverse order so we don't delete the wrong index
+	for i := len(indexes) - 1; i >= 0; i-- {
+		p.Conferences = append(p.Conferences[:indexes[i]], p.Conferences[indexes[i]+1:]...)
 	}
 }
 
+// ConferencePageIterator lets you retrieve consecutive pages of resources. The
+// iterators returned by ConferenceService also implement Prefetcher.
 type ConferencePageIterator interface {
 	// Next returns the next page of resources. If there are no more resources,
 	// NoMoreResults is returned.
@@ -171,6 +181,24 @@ type conferencePageIterator struct {
 	p *PageIterator
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *conferencePageIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *conferencePageIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (c *conferencePageIterator) Cursor() Cursor {
+	return c.p.Cursor()
+}
+
 // GetPageIterator returns a ConferencePageIterator with the given page
 // filters. Call iterator.Next() to get the first page of resources (and again
 // to retrieve subsequent pages).
@@ -180,6 +208,23 @@ func (c *ConferenceService) GetPageIterator(data url.Values) ConferencePageItera
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator. If cur came from a GetConferencesInRange iterator, the
+// returned iterator only returns results in the same range.
+func (c *ConferenceService) ResumePageIterator(cur Cursor) ConferencePageIterator {
+	iter := ResumePageIterator(c.client, conferencePathPart, cur)
+	if cur.inRange() {
+		return &conferenceDateIterator{
+			start: cur.Start,
+			end:   cur.End,
+			p:     iter,
+		}
+	}
+	return &conferencePageIterator{
+		p: iter,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
 // NoMoreResults is returned.
 func (c *conferencePageIterator) Next(ctx context.Context) (*ConferencePage, error) {
@@ -191,3 +236,72 @@ func (c *conferencePageIterator) Next(ctx context.Context) (*ConferencePage, err
 	c.p.SetNextPageURI(cp.NextPageURI)
 	return cp, nil
 }
+
+// ConferenceIterator yields Conferences one at a time. Call Next to advance the
+// iterator, and Value to get the current Conference.
+type ConferenceIterator struct {
+	*ItemIterator
+}
+
+// NewConferenceIterator returns a ConferenceIterator that yields each
+// Conference in the pages returned by p.
+func NewConferenceIterator(p ConferencePageIterator) *ConferenceIterator {
+	return &ConferenceIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.Conferences))
+		for i := range page.Conferences {
+			items[i] = page.Conferences[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current Conference, or nil if Next hasn't been called or
+// returned false.
+func (c *ConferenceIterator) Value() *Conference {
+	v, _ := c.ItemIterator.Value().(*Conference)
+	return v
+}
+
+// GetIterator returns an iterator over the Conferences matching the filters in
+// data, fetching new pages as needed.
+func (c *ConferenceService) GetIterator(data url.Values) *ConferenceIterator {
+	return NewConferenceIterator(c.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (c *ConferenceService) ResumeIterator(cur Cursor) *ConferenceIterator {
+	iter := NewConferenceIterator(c.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
+
+// Stream sends the conferences matching the filters in data to the returned channel,
+// fetching new pages as needed. If the caller doesn't keep up, Stream waits
+// for it before fetching more pages. The conferences channel is closed when there
+// are no more conferences, an error occurs, or ctx is cancelled; then the error
+// channel receives the error (or nil) and is closed. To stop early, cancel
+// ctx.
+func (c *ConferenceService) Stream(ctx context.Context, data url.Values) (<-chan *Conference, <-chan error) {
+	conferences := make(chan *Conference, streamBufferSize)
+	errs := make(chan error, 1)
+	iter := c.GetIterator(data)
+	go func() {
+		err := iter.stream(ctx, func(v interface{}) bool {
+			select {
+			case conferences <- v.(*Conference):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		close(conferences)
+		errs <- err
+		close(errs)
+	}()
+	return conferences, errs
+}
@@ -8,6 +8,11 @@
 //
 //     client := twilio.NewClient("AC123", "123", nil)
 //
+// To avoid deploying your Auth Token, you can authenticate with an API Key
+// instead:
+//
+//     client := twilio.NewClientWithKey("AC123", "SK123", "secret", nil)
+//
 // All of the Twilio resources are available as properties on the Client. Let's
 // walk through some of the example use cases.
 //
@@ -0,0 +1,115 @@
+package twilio
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/kevinburke/rest"
+)
+
+// Error is returned when the Twilio API responds to a request with an error.
+// Use the Code to branch on a specific failure:
+//
+//     _, err := client.Messages.SendMessageContext(ctx, from, to, "hello", nil)
+//     if terr, ok := err.(*twilio.Error); ok && terr.Code == twilio.CodeInvalidToNumber {
+//         // ask the user for a different number
+//     }
+//
+// Not every error returned by this library is an Error - HTTP timeouts and
+// canceled Contexts are returned as plain Go errors.
+type Error struct {
+	// The Twilio error code, for example 20404 or 21211. See
+	// https://www.twilio.com/docs/api/errors/reference for a full list. Code
+	// is zero if the response didn't include one.
+	Code Code
+	// The HTTP status code of the response.
+	StatusCode int
+	// A human readable description of the error.
+	Message string
+	// A URL with more information about the error, for example
+	// "https://www.twilio.com/docs/errors/20404".
+	MoreInfo string
+}
+
+func (e *Error) Error() string {
+	if e.Code == 0 {
+		return fmt.Sprintf("twilio: %s (status %d)", e.Message, e.StatusCode)
+	}
+	return fmt.Sprintf("twilio: %s (error %d, status %d)", e.Message, e.Code, e.StatusCode)
+}
+
+// Unwrap returns the error as a *rest.Error, the type returned by earlier
+// versions of this library, so errors.As can find one.
+func (e *Error) Unwrap() error {
+	return &rest.Error{
+		Title:      e.Message,
+		Type:       e.MoreInfo,
+		ID:         strconv.Itoa(int(e.Code)),
+		StatusCode: e.StatusCode,
+	}
+}
+
+// IsNotFound returns true if the requested resource doesn't exist.
+func (e *Error) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound || e.Code == CodeNotFound
+}
+
+// IsRateLimited returns true if Twilio rejected the request because too many
+// requests were made too quickly.
+func (e *Error) IsRateLimited() bool {
+	return e.StatusCode == 429 || e.Code == CodeTooManyRequests
+}
+
+// IsRetryable returns true if the same request might succeed if it's made
+// again later. Be careful retrying requests that create resources; Twilio may
+// have created the resource even though it returned a server error.
+func (e *Error) IsRetryable() bool {
+	if e.IsRateLimited() || e.StatusCode >= 500 {
+		return true
+	}
+	switch e.Code {
+	case CodeInternalServerError, CodeServiceUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
+// asError returns the first *Error in err's chain, or nil if there isn't one.
+func asError(err error) *Error {
+	for err != nil {
+		if terr, ok := err.(*Error); ok {
+			return terr
+		}
+		u, ok := err.(interface {
+			Unwrap() error
+		})
+		if !ok {
+			return nil
+		}
+		err = u.Unwrap()
+	}
+	return nil
+}
+
+// IsNotFound returns true if err is an *Error for a resource that doesn't
+// exist.
+func IsNotFound(err error) bool {
+	terr := asError(err)
+	return terr != nil && terr.IsNotFound()
+}
+
+// IsRateLimited returns true if err is an *Error indicating too many requests
+// were made too quickly.
+func IsRateLimited(err error) bool {
+	terr := asError(err)
+	return terr != nil && terr.IsRateLimited()
+}
+
+// IsRetryable returns true if err is an *Error for a request that might
+// succeed if it's made again later.
+func IsRetryable(err error) bool {
+	terr := asError(err)
+	return terr != nil && terr.IsRetryable()
+}
@@ -0,0 +1,30 @@
+// +build go1.13
+
+package twilio
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kevinburke/rest"
+)
+
+func TestErrorsAs(t *testing.T) {
+	t.Parallel()
+	err := fmt.Errorf("sending reminder: %w", &Error{Code: CodeLandline, StatusCode: 400, Message: "landline"})
+	var terr *Error
+	if !errors.As(err, &terr) {
+		t.Fatal("expected errors.As to find an Error")
+	}
+	if terr.Code != CodeLandline {
+		t.Errorf("expected Code to be %d, got %d", CodeLandline, terr.Code)
+	}
+	var rerr *rest.Error
+	if !errors.As(err, &rerr) {
+		t.Fatal("expected errors.As to find a rest.Error")
+	}
+	if rerr.ID != "30006" {
+		t.Errorf("expected ID to be 30006, got %s", rerr.ID)
+	}
+}
@@ -0,0 +1,75 @@
+package twilio
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestErrorHelpers(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err         error
+		notFound    bool
+		rateLimited bool
+		retryable   bool
+	}{
+		{&Error{Code: CodeNotFound, StatusCode: 404}, true, false, false},
+		{&Error{Code: CodeTooManyRequests, StatusCode: 429}, false, true, true},
+		{&Error{Code: CodeInvalidToNumber, StatusCode: 400}, false, false, false},
+		{&Error{StatusCode: 502}, false, false, true},
+		{&Error{Code: CodeServiceUnavailable}, false, false, true},
+		{errors.New("twilio: not an API error"), false, false, false},
+		{nil, false, false, false},
+	}
+	for _, tt := range tests {
+		if got := IsNotFound(tt.err); got != tt.notFound {
+			t.Errorf("IsNotFound(%v): got %t, want %t", tt.err, got, tt.notFound)
+		}
+		if got := IsRateLimited(tt.err); got != tt.rateLimited {
+			t.Errorf("IsRateLimited(%v): got %t, want %t", tt.err, got, tt.rateLimited)
+		}
+		if got := IsRetryable(tt.err); got != tt.retryable {
+			t.Errorf("IsRetryable(%v): got %t, want %t", tt.err, got, tt.retryable)
+		}
+	}
+}
+
+func TestInvalidToError(t *testing.T) {
+	t.Parallel()
+	client, s := getServerCode([]byte(`{"code": 21211, "message": "The 'To' number +1foo is not a valid phone number.", "more_info": "https://www.twilio.com/docs/errors/21211", "status": 400}`), 400)
+	defer s.Close()
+	_, err := client.Messages.SendMessage(from, "+1foo", "hello", nil)
+	terr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected an Error, got %#v", err)
+	}
+	if terr.Code != CodeInvalidToNumber {
+		t.Errorf("expected Code to be %d, got %d", CodeInvalidToNumber, terr.Code)
+	}
+	if terr.StatusCode != 400 {
+		t.Errorf("expected StatusCode to be 400, got %d", terr.StatusCode)
+	}
+	want := "twilio: The 'To' number +1foo is not a valid phone number. (error 21211, status 400)"
+	if terr.Error() != want {
+		t.Errorf("Error(): got %q, want %q", terr.Error(), want)
+	}
+}
+
+func TestHTMLErrorBody(t *testing.T) {
+	t.Parallel()
+	client, s := getServerCode([]byte("<html><body>Bad Gateway</body></html>"), 502)
+	defer s.Close()
+	_, err := client.Calls.Get(context.Background(), "CA123")
+	terr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected an Error, got %#v", err)
+	}
+	if terr.StatusCode != 502 || terr.Code != 0 {
+		t.Errorf("bad Error: %#v", terr)
+	}
+	if !terr.IsRetryable() {
+		t.Errorf("expected a 502 to be retryable")
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"net/url"
 	"time"
 
-	"github.com/kevinburke/rest"
 	twilio "github.com/saintpete/twilio-go"
 	"golang.org/x/net/context"
 )
@@ -16,29 +15,30 @@ var callURL, _ = url.Parse("https://kev.inburke.com/zombo/zombocom.mp3")
 func Example() {
 	client := twilio.NewClient("AC123", "123", nil)
 
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+
 	// Send a SMS
-	msg, _ := client.Messages.SendMessage("+14105551234", "+14105556789", "Sent via go :) ✓", nil)
+	msg, _ := client.Messages.SendMessageContext(ctx, "+14105551234", "+14105556789", "Sent via go :) ✓", nil)
 	fmt.Println(msg.Sid, msg.FriendlyPrice())
 
 	// Make a call
-	call, _ := client.Calls.MakeCall("+14105551234", "+14105556789", callURL)
+	call, _ := client.Calls.MakeCallContext(ctx, "+14105551234", "+14105556789", callURL)
 	fmt.Println(call.Sid, call.FriendlyPrice())
 
-	_, err := client.IncomingNumbers.BuyNumber("+1badnumber")
-	// Twilio API errors are converted to rest.Error types
+	_, err := client.IncomingNumbers.BuyNumberContext(ctx, "+1badnumber")
+	// Twilio API errors are converted to twilio.Error types
 	if err != nil {
-		restErr, ok := err.(*rest.Error)
+		terr, ok := err.(*twilio.Error)
 		if ok {
-			fmt.Println(restErr.Title)
-			fmt.Println(restErr.Type)
+			fmt.Println(terr.Code, terr.Message)
+			fmt.Println(terr.MoreInfo)
 		}
 	}
 
 	// Find all calls from a number
 	data := url.Values{"From": []string{"+14105551234"}}
 	iterator := client.Calls.GetPageIterator(data)
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
-	defer cancel()
 	for {
 		page, err := iterator.Next(ctx)
 		if err == twilio.NoMoreResults {
@@ -0,0 +1,85 @@
+package twilio
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// A Hook observes the HTTP requests made by a Client, for example to log them,
+// count them or propagate tracing headers. Either function may be nil.
+//
+// Hooks run for every attempt of every API request, including requests for
+// subsequent pages and each request made while downloading Media or
+// Recordings. For downloads, AfterResponse is called once the headers are
+// read, before the caller reads the body. Hooks run
+// synchronously, on the goroutine making the request, so they should be fast.
+type Hook struct {
+	// BeforeRequest is called right before req is sent. It may modify req's
+	// headers, but shouldn't read or replace the body.
+	BeforeRequest func(ctx context.Context, req *http.Request)
+	// AfterResponse is called once the response to req has been read, or
+	// the request failed.
+	AfterResponse func(ctx context.Context, req *http.Request, info *ResponseInfo)
+}
+
+// ResponseInfo describes the outcome of a single HTTP request.
+type ResponseInfo struct {
+	// The HTTP status code, or 0 if no response was received.
+	StatusCode int
+	// How long the request took, including reading the response body.
+	Duration time.Duration
+	// The Twilio error code in the response, if any.
+	Code Code
+	// The error returned for this attempt, or nil if it succeeded.
+	Err error
+	// Attempt is 1 for the first attempt at a request, 2 for the first retry,
+	// and so on.
+	Attempt int
+}
+
+// AddHook configures c, and the Monitor and Pricing clients attached to it, to
+// call h for every request. Hooks are called in the order they were added.
+// AddHook is not safe to call while requests are in progress.
+func (c *Client) AddHook(h *Hook) {
+	c.Hooks = append(c.Hooks, h)
+	if c.Monitor != nil {
+		c.Monitor.Hooks = append(c.Monitor.Hooks, h)
+	}
+	if c.Pricing != nil {
+		c.Pricing.Hooks = append(c.Pricing.Hooks, h)
+	}
+}
+
+func (c *Client) beforeRequest(ctx context.Context, req *http.Request) {
+	for _, h := range c.Hooks {
+		if h.BeforeRequest != nil {
+			h.BeforeRequest(ctx, req)
+		}
+	}
+}
+
+// afterResponse calls the AfterResponse hooks for a request that started at
+// start. resp may be nil if the request failed before a response was received.
+func (c *Client) afterResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, start time.Time, attempt int) {
+	if len(c.Hooks) == 0 {
+		return
+	}
+	info := &ResponseInfo{
+		Duration: time.Since(start),
+		Err:      err,
+		Attempt:  attempt,
+	}
+	if resp != nil {
+		info.StatusCode = resp.StatusCode
+	}
+	if terr := asError(err); terr != nil {
+		info.Code = terr.Code
+	}
+	for _, h := range c.Hooks {
+		if h.AfterResponse != nil {
+			h.AfterResponse(ctx, req, info)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package twilio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestHooks(t *testing.T) {
+	t.Parallel()
+	var count int32
+	var traceHeader string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceHeader = r.Header.Get("X-Trace-Id")
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if atomic.AddInt32(&count, 1) == 1 {
+			w.WriteHeader(503)
+			w.Write([]byte(`{"code": 20503, "message": "Service unavailable", "status": 503}`))
+			return
+		}
+		w.Write(makeCallResponse)
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetRetryPolicy(fastRetryPolicy)
+	var infos []*ResponseInfo
+	client.AddHook(&Hook{
+		BeforeRequest: func(ctx context.Context, req *http.Request) {
+			req.Header.Set("X-Trace-Id", "abc")
+		},
+		AfterResponse: func(ctx context.Context, req *http.Request, info *ResponseInfo) {
+			infos = append(infos, info)
+		},
+	})
+	if _, err := client.Calls.Get(context.Background(), "CA123"); err != nil {
+		t.Fatal(err)
+	}
+	if traceHeader != "abc" {
+		t.Errorf("expected BeforeRequest to set a header, got %q", traceHeader)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("expected AfterResponse to be called twice, got %d", len(infos))
+	}
+	if infos[0].StatusCode != 503 || infos[0].Code != CodeServiceUnavailable || infos[0].Err == nil || infos[0].Attempt != 1 {
+		t.Errorf("bad info for first attempt: %#v", infos[0])
+	}
+	if infos[1].StatusCode != 200 || infos[1].Code != 0 || infos[1].Err != nil || infos[1].Attempt != 2 {
+		t.Errorf("bad info for second attempt: %#v", infos[1])
+	}
+	if len(client.Monitor.Hooks) != 1 || len(client.Pricing.Hooks) != 1 {
+		t.Errorf("expected AddHook to configure the Monitor and Pricing clients")
+	}
+}
+
+func TestHooksMedia(t *testing.T) {
+	t.Parallel()
+	client, s := getServerCode([]byte(`{"code": 20404, "message": "Not found", "status": 404}`), 404)
+	defer s.Close()
+	var info *ResponseInfo
+	client.AddHook(&Hook{
+		AfterResponse: func(ctx context.Context, req *http.Request, i *ResponseInfo) {
+			info = i
+		},
+	})
+	_, err := client.Media.GetURL(context.Background(), "MM123", "ME123")
+	if err == nil {
+		t.Fatal("expected non-nil error, got nil")
+	}
+	if info == nil {
+		t.Fatal("expected AfterResponse to be called for a Media request")
+	}
+	if info.StatusCode != 404 || info.Code != CodeNotFound {
+		t.Errorf("bad info: %#v", info)
+	}
+}
@@ -1,12 +1,12 @@
 package twilio
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
@@ -52,6 +52,25 @@ type Client struct {
 	AccountSid string
 	AuthToken  string
 
+	// RetryPolicy controls whether and how failed requests are retried. If
+	// nil, every request is attempted exactly once. Use SetRetryPolicy to
+	// configure the Monitor and Pricing clients at the same time.
+	RetryPolicy *RetryPolicy
+	// Limiter, if set, limits the rate and concurrency of requests. Use
+	// SetLimiter to share a Limiter with the Monitor and Pricing clients.
+	Limiter *Limiter
+	// Hooks are called before and after every HTTP request. Use AddHook to
+	// add a Hook to the Monitor and Pricing clients at the same time.
+	Hooks []*Hook
+	// Logger, if set, receives an event for every request and response, with
+	// credentials redacted. Use SetLogger to configure the Monitor and Pricing
+	// clients at the same time.
+	Logger     Logger
+	LogOptions *LogOptions
+	// MediaResolver controls how Media.GetURL finds the URL of a Media
+	// resource's content. If nil, DefaultMediaResolver is used.
+	MediaResolver *MediaResolver
+
 	// The API Client uses these resources
 	Accounts          *AccountService
 	Applications      *ApplicationService
@@ -77,8 +96,8 @@ type Client struct {
 
 const defaultTimeout = 30*time.Second + 500*time.Millisecond
 
-// An error returned by the Twilio API. We don't want to expose this - let's
-// try to standardize on the fields in the HTTP problem spec instead.
+// The error body returned by the Twilio API. parseTwilioError converts this
+// into an Error.
 type twilioError struct {
 	Code     int    `json:"code"`
 	Message  string `json:"message"`
@@ -97,42 +116,74 @@ func parseTwilioError(resp *http.Response) error {
 	}
 	rerr := new(twilioError)
 	err = json.Unmarshal(resBody, rerr)
-	if err != nil {
-		return fmt.Errorf("invalid response body: %s", string(resBody))
+	if err != nil || rerr.Message == "" {
+		// Probably an HTML error page from a proxy; keep the status code so
+		// callers can tell whether it's worth retrying.
+		return &Error{
+			Message:    fmt.Sprintf("invalid response body: %s", string(resBody)),
+			StatusCode: resp.StatusCode,
+		}
 	}
-	if rerr.Message == "" {
-		return fmt.Errorf("invalid response body: %s", string(resBody))
-	}
-	return &rest.Error{
-		Title:      rerr.Message,
-		Type:       rerr.MoreInfo,
-		ID:         strconv.FormatInt(int64(rerr.Code), 10),
+	return &Error{
+		Code:       Code(rerr.Code),
 		StatusCode: resp.StatusCode,
+		Message:    rerr.Message,
+		MoreInfo:   rerr.MoreInfo,
 	}
 }
 
 func NewMonitorClient(accountSid string, authToken string, httpClient *http.Client) *Client {
+	c := newMonitorClient(accountSid, accountSid, authToken, httpClient)
+	c.AuthToken = authToken
+	return c
+}
+
+// newMonitorClient returns a Twilio Monitor client for accountSid that
+// authenticates with the given username and password (either the Account Sid
+// and Auth Token, or an API Key Sid and secret).
+func newMonitorClient(accountSid string, username string, password string, httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: defaultTimeout}
 	}
-	restClient := rest.NewClient(accountSid, authToken, MonitorBaseURL)
-	c := &Client{Client: restClient, AccountSid: accountSid, AuthToken: authToken}
+	restClient := rest.NewClient(username, password, MonitorBaseURL)
+	restClient.Client = httpClient
+	restClient.ErrorParser = parseTwilioError
+	c := &Client{Client: restClient, AccountSid: accountSid}
+	c.APIVersion = MonitorVersion
+	c.initMonitorServices()
+	return c
+}
+
+// initMonitorServices points the Monitor resources at c.
+func (c *Client) initMonitorServices() {
 	c.FullPath = func(pathPart string) string {
 		return "/" + c.APIVersion + "/" + pathPart
 	}
-	c.APIVersion = MonitorVersion
 	c.Alerts = &AlertService{client: c}
-	return c
 }
 
 // returns a new Client to use the pricing API
 func NewPricingClient(accountSid string, authToken string, httpClient *http.Client) *Client {
+	c := newPricingClient(accountSid, accountSid, authToken, httpClient)
+	c.AuthToken = authToken
+	return c
+}
+
+func newPricingClient(accountSid string, username string, password string, httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: defaultTimeout}
 	}
-	restClient := rest.NewClient(accountSid, authToken, PricingBaseURL)
-	c := &Client{Client: restClient, AccountSid: accountSid, AuthToken: authToken}
+	restClient := rest.NewClient(username, password, PricingBaseURL)
+	restClient.Client = httpClient
+	restClient.ErrorParser = parseTwilioError
+	c := &Client{Client: restClient, AccountSid: accountSid}
 	c.APIVersion = PricingVersion
+	c.initPricingServices()
+	return c
+}
+
+// initPricingServices points the Pricing resources at c.
+func (c *Client) initPricingServices() {
 	c.FullPath = func(pathPart string) string {
 		return "/" + c.APIVersion + "/" + pathPart
 	}
@@ -146,31 +197,59 @@ func NewPricingClient(accountSid string, authToken string, httpClient *http.Clie
 	c.PhoneNumbers = &PhoneNumberPriceService{
 		Countries: &CountryPhoneNumberPriceService{client: c},
 	}
-	return c
 }
 
 // NewClient creates a Client for interacting with the Twilio API. This is the
 // main entrypoint for API interactions; view the methods on the subresources
 // for more information.
 func NewClient(accountSid string, authToken string, httpClient *http.Client) *Client {
+	c := newClient(accountSid, accountSid, authToken, httpClient)
+	c.AuthToken = authToken
+	c.Monitor.AuthToken = authToken
+	c.Pricing.AuthToken = authToken
+	return c
+}
 
+// NewClientWithKey creates a Client that authenticates with an API Key (see
+// KeyService.Create) instead of your Account's Auth Token, so the Auth Token
+// doesn't need to be deployed with your application. keySid is the Sid of the
+// Key (e.g. "SK123") and keySecret is the Secret returned when the Key was
+// created. Requests are still made against the Account with the given
+// accountSid.
+//
+// The Monitor and Pricing clients, and the requests made to download Media,
+// authenticate with the same Key. The AuthToken field of the returned Client
+// is empty.
+func NewClientWithKey(accountSid string, keySid string, keySecret string, httpClient *http.Client) *Client {
+	return newClient(accountSid, keySid, keySecret, httpClient)
+}
+
+// newClient returns a Client for accountSid that authenticates with the
+// given username and password.
+func newClient(accountSid string, username string, password string, httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: defaultTimeout}
 	}
-	restClient := rest.NewClient(accountSid, authToken, BaseURL)
+	restClient := rest.NewClient(username, password, BaseURL)
 	restClient.Client = httpClient
 	restClient.UploadType = rest.FormURLEncoded
 	restClient.ErrorParser = parseTwilioError
 
-	c := &Client{Client: restClient, AccountSid: accountSid, AuthToken: authToken}
+	c := &Client{Client: restClient, AccountSid: accountSid}
 	c.APIVersion = APIVersion
 
 	c.FullPath = func(pathPart string) string {
 		return "/" + strings.Join([]string{c.APIVersion, "Accounts", c.AccountSid, pathPart + ".json"}, "/")
 	}
-	c.Monitor = NewMonitorClient(accountSid, authToken, httpClient)
-	c.Pricing = NewPricingClient(accountSid, authToken, httpClient)
+	c.Monitor = newMonitorClient(accountSid, username, password, httpClient)
+	c.Pricing = newPricingClient(accountSid, username, password, httpClient)
+
+	c.initServices()
+	return c
+}
 
+// initServices points the API resources at c.
+func (c *Client) initServices() {
 	c.Accounts = &AccountService{client: c}
 	c.Applications = &ApplicationService{client: c}
 	c.Calls = &CallService{client: c}
@@ -198,7 +277,52 @@ func NewClient(accountSid string, authToken string, httpClient *http.Client) *Cl
 			pathPart: "TollFree",
 		},
 	}
-	return c
+}
+
+// ForSubaccount returns a new Client that makes requests on behalf of the
+// subaccount with the given sid, using c's credentials for Basic Auth. All of
+// the api.twilio.com resources on the returned Client (Calls, Messages,
+// IncomingNumbers, etc) operate on the subaccount.
+//
+// ForSubaccount is safe to call concurrently, and doesn't modify c. The
+// returned Client starts with c's HTTP client, credentials, RetryPolicy,
+// Limiter, Hooks and Logger, so it's cheap to create one per request.
+//
+// The returned Client has its own copies of the Monitor and Pricing clients,
+// which still make requests with c's credentials: Pricing information doesn't
+// vary by account, and Twilio Monitor returns Alerts for the account that
+// owns the credentials. Configuring the returned Client, for example with
+// AddHook or SetLimiter, doesn't affect c or its Monitor and Pricing clients.
+func (c *Client) ForSubaccount(subaccountSid string) *Client {
+	sub := c.clone()
+	sub.AccountSid = subaccountSid
+	sub.FullPath = func(pathPart string) string {
+		return "/" + strings.Join([]string{sub.APIVersion, "Accounts", sub.AccountSid, pathPart + ".json"}, "/")
+	}
+	sub.initServices()
+	if c.Monitor != nil {
+		sub.Monitor = c.Monitor.clone()
+		sub.Monitor.initMonitorServices()
+	}
+	if c.Pricing != nil {
+		sub.Pricing = c.Pricing.clone()
+		sub.Pricing.initPricingServices()
+	}
+	return sub
+}
+
+// clone returns a copy of c with its own Hooks slice and rest.Client, so
+// changing the copy's Hooks or Base doesn't change c. The copy's FullPath and
+// services still point at c.
+func (c *Client) clone() *Client {
+	c2 := new(Client)
+	*c2 = *c
+	if c.Client != nil {
+		rc := *c.Client
+		c2.Client = &rc
+	}
+	c2.Hooks = append([]*Hook(nil), c.Hooks...)
+	return c2
 }
 
 // RequestOnBehalfOf will make all future client requests using the same
@@ -207,7 +331,8 @@ func NewClient(accountSid string, authToken string, httpClient *http.Client) *Cl
 // subaccount, using the parent account's credentials.
 //
 // RequestOnBehalfOf is *not* thread safe, and modifies the Client's behavior
-// for all requests going forward.
+// for all requests going forward. Use ForSubaccount to get a separate Client
+// for the subaccount instead.
 //
 // RequestOnBehalfOf should only be used with api.twilio.com, not (for example)
 // Twilio Monitor.
@@ -240,11 +365,7 @@ func (c *Client) UpdateResource(ctx context.Context, pathPart string, sid string
 func (c *Client) DeleteResource(ctx context.Context, pathPart string, sid string) error {
 	sidPart := strings.Join([]string{pathPart, sid}, "/")
 	err := c.MakeRequest(ctx, "DELETE", sidPart, nil, nil)
-	if err == nil {
-		return nil
-	}
-	rerr, ok := err.(*rest.Error)
-	if ok && rerr.StatusCode == http.StatusNotFound {
+	if err == nil || IsNotFound(err) {
 		return nil
 	}
 	return err
@@ -265,27 +386,91 @@ func (c *Client) GetNextPage(ctx context.Context, fullUri string, v interface{})
 	return c.MakeRequest(ctx, "GET", fullUri, nil, v)
 }
 
-// Make a request to the Twilio API.
+// Make a request to the Twilio API. If c has a RetryPolicy, requests that fail
+// for temporary reasons are retried, as long as the ctx deadline allows it.
 func (c *Client) MakeRequest(ctx context.Context, method string, pathPart string, data url.Values, v interface{}) error {
 	if !strings.HasPrefix(pathPart, "/"+c.APIVersion) {
 		pathPart = c.FullPath(pathPart)
 	}
-	rb := new(strings.Reader)
+	body := ""
 	if data != nil && (method == "POST" || method == "PUT") {
-		rb = strings.NewReader(data.Encode())
+		body = data.Encode()
 	}
 	if method == "GET" && data != nil {
 		pathPart = pathPart + "?" + data.Encode()
 	}
-	req, err := c.NewRequest(method, pathPart, rb)
+	for attempt := 1; ; attempt++ {
+		req, err := c.NewRequest(method, pathPart, strings.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req = withContext(req, ctx)
+		if ua := req.Header.Get("User-Agent"); ua == "" {
+			req.Header.Set("User-Agent", userAgent)
+		} else {
+			req.Header.Set("User-Agent", userAgent+" "+ua)
+		}
+		release, err := c.wait(ctx, pathPart)
+		if err != nil {
+			return err
+		}
+		c.beforeRequest(ctx, req)
+		start := time.Now()
+		resp, err := c.do(ctx, req, body, attempt, v)
+		c.afterResponse(ctx, req, resp, err, start, attempt)
+		release()
+		if err == nil {
+			return nil
+		}
+		policy := c.RetryPolicy
+		if policy == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+		if !shouldRetry(method, resp, err) {
+			return err
+		}
+		wait := policy.backoff(attempt)
+		if ra := retryAfter(resp, time.Now()); ra > 0 {
+			wait = ra
+		}
+		if !sleep(ctx, wait) {
+			return err
+		}
+	}
+}
+
+// do sends req and decodes a successful response into v. If the server
+// returns an error status code, the parsed error is returned alongside the
+// response, so callers can inspect the headers. The response body is always
+// closed before do returns.
+func (c *Client) do(ctx context.Context, req *http.Request, reqBody string, attempt int, v interface{}) (*http.Response, error) {
+	c.logRequest(ctx, req, []byte(reqBody))
+	httpClient := c.Client.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	start := time.Now()
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return err
+		c.logResponse(ctx, req, nil, nil, err, start, attempt)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	resBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		c.logResponse(ctx, req, resp, nil, err, start, attempt)
+		return resp, err
 	}
-	req = withContext(req, ctx)
-	if ua := req.Header.Get("User-Agent"); ua == "" {
-		req.Header.Set("User-Agent", userAgent)
-	} else {
-		req.Header.Set("User-Agent", userAgent+" "+ua)
+	if resp.StatusCode >= 400 {
+		parser := c.ErrorParser
+		if parser == nil {
+			parser = parseTwilioError
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+		err = parser(resp)
+	} else if v != nil && resp.StatusCode != http.StatusNoContent && len(resBody) > 0 {
+		err = json.Unmarshal(resBody, v)
 	}
-	return c.Do(req, &v)
+	c.logResponse(ctx, req, resp, resBody, err, start, attempt)
+	return resp, err
 }
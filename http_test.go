@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -31,21 +32,31 @@ func Test404Error(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected non-nil error, got nil")
 	}
-	rerr, ok := err.(*rest.Error)
+	terr, ok := err.(*Error)
 	if !ok {
-		t.Fatalf("expected to convert err %v to rest.Error, couldn't", err)
+		t.Fatalf("expected to convert err %v to Error, couldn't", err)
 	}
-	if !strings.Contains(rerr.Title, "The requested resource /2010-04-01") {
-		t.Errorf("expected Title to contain 'The requested resource', got %s", rerr.Title)
+	if !strings.Contains(terr.Message, "The requested resource /2010-04-01") {
+		t.Errorf("expected Message to contain 'The requested resource', got %s", terr.Message)
 	}
-	if rerr.ID != "20404" {
-		t.Errorf("expected ID to be 20404, got %s", rerr.ID)
+	if terr.Code != CodeNotFound {
+		t.Errorf("expected Code to be 20404, got %d", terr.Code)
 	}
-	if rerr.Type != "https://www.twilio.com/docs/errors/20404" {
-		t.Errorf("expected Type to be a Twilio URL, got %s", rerr.Type)
+	if terr.MoreInfo != "https://www.twilio.com/docs/errors/20404" {
+		t.Errorf("expected MoreInfo to be a Twilio URL, got %s", terr.MoreInfo)
 	}
-	if rerr.StatusCode != 404 {
-		t.Errorf("expected StatusCode to be 404, got %d", rerr.StatusCode)
+	if terr.StatusCode != 404 {
+		t.Errorf("expected StatusCode to be 404, got %d", terr.StatusCode)
+	}
+	if !IsNotFound(err) {
+		t.Errorf("expected IsNotFound to be true")
+	}
+	rerr, ok := terr.Unwrap().(*rest.Error)
+	if !ok {
+		t.Fatalf("expected Unwrap to return a rest.Error, got %#v", terr.Unwrap())
+	}
+	if rerr.ID != "20404" || rerr.StatusCode != 404 {
+		t.Errorf("bad rest.Error: %#v", rerr)
 	}
 }
 
@@ -108,3 +119,147 @@ func TestOnBehalfOf(t *testing.T) {
 		t.Errorf("expected called to be true, got false")
 	}
 }
+
+func TestForSubaccount(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	paths := make(map[string]bool)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != "AC123" || pass != "456bef" {
+			t.Errorf("expected parent credentials, got %s:%s", user, pass)
+		}
+		mu.Lock()
+		paths[r.URL.Path] = true
+		mu.Unlock()
+		w.WriteHeader(200)
+		w.Write([]byte("{}"))
+	}))
+	defer s.Close()
+	c := NewClient("AC123", "456bef", nil)
+	c.Base = s.URL
+	var wg sync.WaitGroup
+	for _, sid := range []string{"AC345", "AC678"} {
+		wg.Add(1)
+		go func(sid string) {
+			defer wg.Done()
+			sub := c.ForSubaccount(sid)
+			if sub.AccountSid != sid {
+				t.Errorf("expected AccountSid to be %s, got %s", sid, sub.AccountSid)
+			}
+			sub.Calls.Get(context.Background(), "CA123")
+			sub.Messages.Get(context.Background(), "SM123")
+			sub.IncomingNumbers.Local.Create(context.Background(), nil)
+		}(sid)
+	}
+	c.Calls.Get(context.Background(), "CA123")
+	wg.Wait()
+	want := []string{
+		"/2010-04-01/Accounts/AC123/Calls/CA123.json",
+		"/2010-04-01/Accounts/AC345/Calls/CA123.json",
+		"/2010-04-01/Accounts/AC345/Messages/SM123.json",
+		"/2010-04-01/Accounts/AC345/IncomingPhoneNumbers/Local.json",
+		"/2010-04-01/Accounts/AC678/Calls/CA123.json",
+	}
+	for _, path := range want {
+		if !paths[path] {
+			t.Errorf("expected a request to %s, got %v", path, paths)
+		}
+	}
+	if len(paths) != 7 {
+		t.Errorf("expected 7 distinct paths, got %d: %v", len(paths), paths)
+	}
+	if c.AccountSid != "AC123" {
+		t.Errorf("ForSubaccount modified the parent Client's AccountSid")
+	}
+}
+
+func TestForSubaccountDoesntModifyParent(t *testing.T) {
+	t.Parallel()
+	c := NewClient("AC123", "456bef", nil)
+	for i := 0; i < 3; i++ {
+		c.AddHook(&Hook{})
+	}
+	sub := c.ForSubaccount("AC345")
+	h := &Hook{}
+	sub.AddHook(h)
+	sub.SetLimiter(NewLimiter(1, 1, 1))
+	sub.Base = "http://example.com"
+	if len(c.Hooks) != 3 || len(c.Monitor.Hooks) != 3 || len(c.Pricing.Hooks) != 3 {
+		t.Errorf("AddHook on the subaccount client changed the parent's hooks")
+	}
+	// the parent's slices have spare capacity, so an append that shares the
+	// backing array would write the new hook past the end.
+	if all := c.Hooks[:cap(c.Hooks)]; len(all) > 3 && all[3] == h {
+		t.Errorf("AddHook on the subaccount client wrote to the parent's Hooks")
+	}
+	if c.Limiter != nil || c.Monitor.Limiter != nil || c.Pricing.Limiter != nil {
+		t.Errorf("SetLimiter on the subaccount client changed the parent")
+	}
+	if c.Base == sub.Base {
+		t.Errorf("changing the subaccount client's Base changed the parent's")
+	}
+	if len(sub.Monitor.Hooks) != 4 || len(sub.Pricing.Hooks) != 4 || sub.Monitor.Limiter == nil {
+		t.Errorf("expected the subaccount's Monitor and Pricing clients to be configured")
+	}
+	if sub.Monitor.Alerts.client != sub.Monitor || sub.Pricing.Voice.Countries.client != sub.Pricing {
+		t.Errorf("expected the subaccount's Monitor and Pricing services to use its clients")
+	}
+}
+
+func TestClientWithKey(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	paths := make([]string, 0)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "SK123" || pass != "secret" {
+			t.Errorf("%s: expected to authenticate with the key, got %s:%s", r.URL.Path, user, pass)
+		}
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		if strings.Contains(r.URL.Path, "/Media/") {
+			w.Header().Set("Location", "https://s3-external-1.amazonaws.com/media.twiliocdn.com/AC123/abc")
+			w.WriteHeader(302)
+			return
+		}
+		w.WriteHeader(200)
+		w.Write([]byte("{}"))
+	}))
+	defer s.Close()
+	c := NewClientWithKey("AC123", "SK123", "secret", nil)
+	c.Base = s.URL
+	c.Monitor.Base = s.URL
+	c.Pricing.Base = s.URL
+	ctx := context.Background()
+	if _, err := c.Calls.Get(ctx, "CA123"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Monitor.Alerts.Get(ctx, "NO123"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Pricing.Voice.Countries.Get(ctx, "US"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Media.GetURL(ctx, "MM123", "ME123"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"/2010-04-01/Accounts/AC123/Calls/CA123.json",
+		"/v1/Alerts/NO123",
+		"/v1/Voice/Countries/US",
+		"/2010-04-01/Accounts/AC123/Messages/MM123/Media/ME123",
+	}
+	if len(paths) != len(want) {
+		t.Fatalf("expected %d requests, got %v", len(want), paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("request %d: got path %s, want %s", i, paths[i], want[i])
+		}
+	}
+	if c.AuthToken != "" {
+		t.Errorf("expected AuthToken to be empty, got %s", c.AuthToken)
+	}
+}
@@ -0,0 +1,170 @@
+// Command gencodes generates error_codes_gen.go, the table of every Twilio
+// error code, from the list Twilio publishes. Run it with go generate from the
+// root of the repository:
+//
+//	go generate
+//
+// or pass -in to generate the table from a copy of the list on disk.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultURL is the address of Twilio's list of error codes.
+const DefaultURL = "https://www.twilio.com/docs/api/errors/twilio-error-codes.json"
+
+// twilioCode is an entry in Twilio's list of error codes.
+type twilioCode struct {
+	Code             int    `json:"code"`
+	Message          string `json:"message"`
+	SecondaryMessage string `json:"secondary_message"`
+	LogLevel         string `json:"log_level"`
+	Product          string `json:"product"`
+}
+
+func main() {
+	url := flag.String("url", DefaultURL, "URL of Twilio's list of error codes")
+	in := flag.String("in", "", "read the list from this file instead of -url")
+	out := flag.String("o", "error_codes_gen.go", "file to write")
+	flag.Parse()
+	var r io.Reader
+	source := *url
+	if *in != "" {
+		f, err := os.Open(*in)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		r = f
+		source = *in
+	} else {
+		resp, err := http.Get(*url)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != 200 {
+			log.Fatalf("gencodes: GET %s: %s", *url, resp.Status)
+		}
+		r = resp.Body
+	}
+	var codes []twilioCode
+	if err := json.NewDecoder(r).Decode(&codes); err != nil {
+		log.Fatalf("gencodes: couldn't parse %s: %v", source, err)
+	}
+	src, err := generate(source, codes)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the formatted source of error_codes_gen.go for codes.
+func generate(source string, codes []twilioCode) ([]byte, error) {
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("gencodes: no error codes in %s", source)
+	}
+	sort.Sort(byCode(codes))
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "// Code generated by internal/gencodes from %s; DO NOT EDIT.\n\n", source)
+	buf.WriteString(`package twilio
+
+func init() {
+	// The hand-written entries in commonCodes take precedence.
+	for code, info := range generatedCodes {
+		if _, ok := commonCodes[code]; !ok {
+			commonCodes[code] = info
+		}
+	}
+}
+
+var generatedCodes = map[Code]codeInfo{
+`)
+	seen := make(map[int]bool)
+	for _, c := range codes {
+		if c.Code <= 0 || seen[c.Code] {
+			continue
+		}
+		seen[c.Code] = true
+		title, description := clean(c.Message), clean(c.SecondaryMessage)
+		if description == "" {
+			description = title
+		}
+		fmt.Fprintf(buf, "\t%d: {%q, %q, %s, %s},\n", c.Code, title, description, category(c), logLevel(c.LogLevel))
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+// clean collapses the whitespace in s.
+func clean(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// category returns the Category constant for c, from its range, or, for codes
+// outside the ranges with a single category, from the product Twilio lists it
+// under. Codes with a product we don't have a category for use the product
+// name, and the rest use CategoryAPI.
+func category(c twilioCode) string {
+	switch {
+	case c.Code >= 10000 && c.Code < 11000:
+		return "CategoryAccount"
+	case c.Code >= 11000 && c.Code < 12000:
+		return "CategoryHTTP"
+	case c.Code >= 12000 && c.Code < 14000:
+		return "CategoryTwiML"
+	case c.Code >= 14000 && c.Code < 15000:
+		return "CategoryMessaging"
+	case c.Code >= 20000 && c.Code < 21000:
+		return "CategoryAPI"
+	case c.Code >= 30000 && c.Code < 31000:
+		return "CategoryCarrier"
+	case c.Code >= 31000 && c.Code < 32000:
+		return "CategoryClient"
+	}
+	product := strings.ToLower(c.Product)
+	switch {
+	case strings.Contains(product, "phone number"):
+		return "CategoryPhoneNumbers"
+	case strings.Contains(product, "messaging") || strings.Contains(product, "sms"):
+		return "CategoryMessaging"
+	case strings.Contains(product, "voice"):
+		return "CategoryVoice"
+	case c.Product != "":
+		return fmt.Sprintf("%q", clean(c.Product))
+	}
+	return "CategoryAPI"
+}
+
+// logLevel returns the LogLevel constant for the level in Twilio's list.
+func logLevel(level string) string {
+	switch strings.ToLower(level) {
+	case "warning", "warn":
+		return "LogLevelWarning"
+	case "notice":
+		return "LogLevelNotice"
+	case "debug":
+		return "LogLevelDebug"
+	}
+	return "LogLevelError"
+}
+
+type byCode []twilioCode
+
+func (b byCode) Len() int           { return len(b) }
+func (b byCode) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byCode) Less(i, j int) bool { return b[i].Code < b[j].Code }
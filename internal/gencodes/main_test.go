@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const sampleCodes = `[
+	{"code": 30003, "message": "Unreachable destination handset", "secondary_message": "The destination\n handset is switched off.", "log_level": "ERROR", "product": "Programmable SMS"},
+	{"code": 13310, "message": "Gather: Invalid finishOnKey value", "secondary_message": "", "log_level": "WARNING", "product": "Programmable Voice"},
+	{"code": 13310, "message": "duplicate", "log_level": "ERROR"},
+	{"code": 21606, "message": "The From phone number is not a valid, SMS-capable inbound phone number", "log_level": "ERROR", "product": "Programmable SMS"}
+]`
+
+func TestGenerate(t *testing.T) {
+	var codes []twilioCode
+	if err := json.Unmarshal([]byte(sampleCodes), &codes); err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate("codes.json", codes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(src)
+	for _, want := range []string{
+		"// Code generated by internal/gencodes from codes.json; DO NOT EDIT.",
+		`13310: {"Gather: Invalid finishOnKey value", "Gather: Invalid finishOnKey value", CategoryTwiML, LogLevelWarning},`,
+		`21606: {"The From phone number is not a valid, SMS-capable inbound phone number", "The From phone number is not a valid, SMS-capable inbound phone number", CategoryMessaging, LogLevelError},`,
+		`30003: {"Unreachable destination handset", "The destination handset is switched off.", CategoryCarrier, LogLevelError},`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "duplicate") {
+		t.Errorf("expected duplicate codes to be skipped, got\n%s", out)
+	}
+	if _, err := generate("empty.json", nil); err == nil {
+		t.Errorf("expected an error for an empty list")
+	}
+}
@@ -0,0 +1,177 @@
+package twilio
+
+import "golang.org/x/net/context"
+
+// streamBufferSize is the size of the channels returned by the Stream
+// methods; it matches Twilio's default page size.
+const streamBufferSize = 50
+
+// An ItemIterator yields the resources in a list one at a time, fetching
+// a new page whenever the previous one is used up. Each resource has an
+// iterator type that wraps an ItemIterator and returns the right type from
+// Value, for example a CallIterator returns a *Call:
+//
+//     iter := client.Calls.GetIterator(data)
+//     for iter.Next(ctx) {
+//         call := iter.Value()
+//     }
+//     if err := iter.Err(); err != nil {
+//         return err
+//     }
+//
+// An ItemIterator is not safe for concurrent use.
+type ItemIterator struct {
+	// next returns the items in the next page, or an error. It returns
+	// NoMoreResults after the last page.
+	next func(ctx context.Context) ([]interface{}, error)
+	// pages is the underlying page iterator, or nil if it can't prefetch.
+	pages Prefetcher
+	// cp is the underlying page iterator, or nil if it can't report a
+	// Cursor. start is its Cursor from before the current page was fetched,
+	// and offset is the number of items consumed from the current page.
+	cp     Checkpointer
+	start  Cursor
+	offset int
+	// skip is the number of items to drop from the next page, when resuming
+	// from a Cursor.
+	skip int
+
+	buf   []interface{}
+	cur   interface{}
+	err   error
+	done  bool
+	limit int
+	count int
+}
+
+// newItemIterator returns an ItemIterator that gets pages by calling next.
+// If pages implements Prefetcher, Prefetch and Stop are passed through to it.
+func newItemIterator(pages interface{}, next func(ctx context.Context) ([]interface{}, error)) *ItemIterator {
+	p, _ := pages.(Prefetcher)
+	cp, _ := pages.(Checkpointer)
+	return &ItemIterator{next: next, pages: p, cp: cp}
+}
+
+// Next advances the iterator to the next item, which will then be available
+// through the Value method. It returns false when there are no more items,
+// the limit has been reached, Stop has been called, or an error occurs. After
+// Next returns false, check Err to see whether an error occurred.
+func (it *ItemIterator) Next(ctx context.Context) bool {
+	it.cur = nil
+	if it.done {
+		return false
+	}
+	if it.limit > 0 && it.count >= it.limit {
+		it.Stop()
+		return false
+	}
+	for len(it.buf) == 0 {
+		if it.cp != nil {
+			it.start = it.cp.Cursor()
+		}
+		items, err := it.next(ctx)
+		if err == NoMoreResults {
+			it.Stop()
+			return false
+		}
+		if err != nil {
+			it.err = err
+			it.Stop()
+			return false
+		}
+		it.offset = 0
+		if it.skip > 0 {
+			n := it.skip
+			if n > len(items) {
+				n = len(items)
+			}
+			items = items[n:]
+			it.offset = n
+			it.skip = 0
+		}
+		it.buf = items
+	}
+	it.cur = it.buf[0]
+	it.buf = it.buf[1:]
+	it.offset++
+	it.count++
+	return true
+}
+
+// Cursor returns the position of the iterator, including how many items of
+// the current page have been returned. If the underlying page iterator
+// doesn't implement Checkpointer, Cursor returns the zero Cursor.
+func (it *ItemIterator) Cursor() Cursor {
+	if it.cp == nil {
+		return Cursor{}
+	}
+	if len(it.buf) == 0 {
+		// The current page is used up, so start from the next one.
+		return it.cp.Cursor()
+	}
+	c := it.start
+	c.Offset = it.offset
+	return c
+}
+
+// resume configures the iterator to skip the items of the first page that
+// were already returned before c was recorded.
+func (it *ItemIterator) resume(c Cursor) {
+	it.skip = c.Offset
+}
+
+// Value returns the current item, or nil if Next hasn't been called or
+// returned false.
+func (it *ItemIterator) Value() interface{} {
+	return it.cur
+}
+
+// Err returns the error that caused Next to return false, or nil if the
+// iterator reached the end of the list, the limit, or was stopped.
+func (it *ItemIterator) Err() error {
+	return it.err
+}
+
+// SetLimit configures the iterator to stop after yielding n items. Zero
+// means no limit.
+func (it *ItemIterator) SetLimit(n int) {
+	it.limit = n
+}
+
+// Prefetch configures the iterator to fetch up to depth pages ahead in the
+// background, while the caller works through the current page. It must be
+// called before the first call to Next. See PageIterator.Prefetch for
+// details. Prefetch does nothing if the underlying page iterator doesn't
+// implement Prefetcher.
+func (it *ItemIterator) Prefetch(depth int) {
+	if it.pages != nil {
+		it.pages.Prefetch(depth)
+	}
+}
+
+// Stop ends the iteration early. Subsequent calls to Next return false, and
+// no more pages are fetched; any prefetch requests in flight are cancelled.
+func (it *ItemIterator) Stop() {
+	it.done = true
+	if it.pages != nil {
+		it.pages.Stop()
+	}
+}
+
+// stream calls send with each item until there are no more items, or ctx is
+// done. send should return false if ctx is done before it can hand off the
+// item. stream returns ctx.Err() if ctx was done, the iteration error, or nil.
+// It stops the iterator before returning.
+func (it *ItemIterator) stream(ctx context.Context, send func(v interface{}) bool) error {
+	defer it.Stop()
+	for it.Next(ctx) {
+		if !send(it.Value()) {
+			return ctx.Err()
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		// the iteration error is probably a wrapped version of this
+		return err
+	}
+	return it.Err()
+}
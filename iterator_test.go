@@ -0,0 +1,170 @@
+package twilio
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// pages returns an ItemIterator that yields the given pages, and records how
+// many pages were fetched in count.
+func pages(count *int, err error, items ...[]interface{}) *ItemIterator {
+	return newItemIterator(nil, func(ctx context.Context) ([]interface{}, error) {
+		if *count >= len(items) {
+			if err != nil {
+				return nil, err
+			}
+			return nil, NoMoreResults
+		}
+		*count++
+		return items[*count-1], nil
+	})
+}
+
+func TestItemIterator(t *testing.T) {
+	t.Parallel()
+	count := 0
+	iter := pages(&count, nil, []interface{}{1, 2}, []interface{}{}, []interface{}{3})
+	var got []interface{}
+	for iter.Next(context.Background()) {
+		got = append(got, iter.Value())
+	}
+	if err := iter.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+	if iter.Next(context.Background()) || iter.Value() != nil {
+		t.Errorf("expected Next to keep returning false at the end")
+	}
+}
+
+func TestItemIteratorError(t *testing.T) {
+	t.Parallel()
+	count := 0
+	wantErr := errors.New("boom")
+	iter := pages(&count, wantErr, []interface{}{1})
+	if !iter.Next(context.Background()) {
+		t.Fatal("expected one item")
+	}
+	if iter.Next(context.Background()) {
+		t.Fatal("expected Next to return false")
+	}
+	if iter.Err() != wantErr {
+		t.Errorf("expected Err to be %v, got %v", wantErr, iter.Err())
+	}
+}
+
+func TestItemIteratorStop(t *testing.T) {
+	t.Parallel()
+	count := 0
+	iter := pages(&count, nil, []interface{}{1}, []interface{}{2})
+	if !iter.Next(context.Background()) {
+		t.Fatal("expected one item")
+	}
+	iter.Stop()
+	if iter.Next(context.Background()) {
+		t.Errorf("expected Next to return false after Stop")
+	}
+	if count != 1 {
+		t.Errorf("expected 1 page to be fetched, got %d", count)
+	}
+	if iter.Err() != nil {
+		t.Errorf("expected nil error after Stop, got %v", iter.Err())
+	}
+}
+
+func TestIteratorLimit(t *testing.T) {
+	t.Parallel()
+	// accountList always has a next page, with 2 accounts per page.
+	client, s := getServer(accountList)
+	defer s.Close()
+	iter := client.Accounts.GetIterator(nil)
+	iter.SetLimit(5)
+	count := 0
+	for iter.Next(context.Background()) {
+		if iter.Value().Sid == "" {
+			t.Errorf("expected account to have a sid")
+		}
+		count++
+	}
+	if err := iter.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 5 {
+		t.Errorf("expected 5 accounts, got %d", count)
+	}
+	if l := len(s.URLs); l != 3 {
+		t.Errorf("expected 3 page requests, got %d", l)
+	}
+}
+
+func TestConferenceIteratorInRange(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(conferencePage)
+	defer s.Close()
+	iter := NewConferenceIterator(client.Conferences.GetConferencesInRange(Epoch, HeatDeath, nil))
+	iter.SetLimit(1)
+	if !iter.Next(context.Background()) {
+		t.Fatal(iter.Err())
+	}
+	if conf := iter.Value(); conf == nil || conf.Sid == "" {
+		t.Errorf("expected a conference, got %v", conf)
+	}
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+	ps := newPagingServer(4)
+	defer ps.Close()
+	msgs, errs := ps.client().Messages.Stream(context.Background(), nil)
+	var sids []string
+	for msg := range msgs {
+		sids = append(sids, msg.Sid)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if len(sids) != 4 || sids[0] != "SM0" || sids[3] != "SM3" {
+		t.Errorf("expected 4 messages in order, got %v", sids)
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	t.Parallel()
+	ps := newPagingServer(0)
+	defer ps.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	alerts, errs := ps.client().Monitor.Alerts.Stream(ctx, nil)
+	for i := 0; i < 3; i++ {
+		if alert := <-alerts; alert == nil || alert.Sid != fmt.Sprintf("NO%d", i) {
+			t.Fatalf("expected alert %d, got %v", i, alert)
+		}
+	}
+	cancel()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-alerts:
+			if ok {
+				continue
+			}
+		case <-timeout:
+			t.Fatal("alerts channel wasn't closed after cancel")
+		}
+		break
+	}
+	if err := <-errs; err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	// Backpressure: the stream can't get more than a buffer's worth of
+	// alerts (and a page) ahead of the reader.
+	if c := ps.count(); c > streamBufferSize+2 {
+		t.Errorf("expected at most %d requests, got %d", streamBufferSize+2, c)
+	}
+}
@@ -68,6 +68,24 @@ type KeyPageIterator struct {
 	p *PageIterator
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *KeyPageIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *KeyPageIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (c *KeyPageIterator) Cursor() Cursor {
+	return c.p.Cursor()
+}
+
 // GetPageIterator returns a KeyPageIterator with the given page
 // filters. Call iterator.Next() to get the first page of resources (and again
 // to retrieve subsequent pages).
@@ -78,6 +96,15 @@ func (c *KeyService) GetPageIterator(data url.Values) *KeyPageIterator {
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the
+// Cursor of an earlier iterator.
+func (c *KeyService) ResumePageIterator(cur Cursor) *KeyPageIterator {
+	iter := ResumePageIterator(c.client, keyPathPart, cur)
+	return &KeyPageIterator{
+		p: iter,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
 // NoMoreResults is returned.
 func (c *KeyPageIterator) Next(ctx context.Context) (*KeyPage, error) {
@@ -89,3 +116,46 @@ func (c *KeyPageIterator) Next(ctx context.Context) (*KeyPage, error) {
 	c.p.SetNextPageURI(kp.NextPageURI)
 	return kp, nil
 }
+
+// KeyIterator yields Keys one at a time. Call Next to advance the iterator, and
+// Value to get the current Key.
+type KeyIterator struct {
+	*ItemIterator
+}
+
+// NewKeyIterator returns a KeyIterator that yields each Key in the pages
+// returned by p.
+func NewKeyIterator(p *KeyPageIterator) *KeyIterator {
+	return &KeyIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.Keys))
+		for i := range page.Keys {
+			items[i] = page.Keys[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current Key, or nil if Next hasn't been called or returned
+// false.
+func (k *KeyIterator) Value() *Key {
+	v, _ := k.ItemIterator.Value().(*Key)
+	return v
+}
+
+// GetIterator returns an iterator over the Keys matching the filters in
+// data, fetching new pages as needed.
+func (c *KeyService) GetIterator(data url.Values) *KeyIterator {
+	return NewKeyIterator(c.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (c *KeyService) ResumeIterator(cur Cursor) *KeyIterator {
+	iter := NewKeyIterator(c.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
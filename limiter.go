@@ -0,0 +1,201 @@
+package twilio
+
+import (
+	"math"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// A Limiter caps the rate and the concurrency of requests made by a Client,
+// so bulk jobs don't run into Twilio's per-account limits (and 429/20429
+// errors). A Limiter is safe for concurrent use and is meant to be shared;
+// attach it to a Client with SetLimiter.
+//
+// Each request takes a token from a token bucket before it is sent. By
+// default every request draws from the same bucket; use SetResourceLimit to
+// give a resource like "Messages" its own bucket.
+type Limiter struct {
+	// nil if concurrency is unlimited.
+	inFlight chan struct{}
+
+	mu            sync.Mutex
+	defaultBucket *bucket
+	buckets       map[string]*bucket
+}
+
+// NewLimiter returns a Limiter that allows requestsPerSecond requests per
+// second, with bursts of up to burst requests, and at most maxInFlight
+// requests in progress at a time. A requestsPerSecond or maxInFlight of zero
+// disables that limit.
+func NewLimiter(requestsPerSecond float64, burst int, maxInFlight int) *Limiter {
+	l := &Limiter{
+		defaultBucket: newBucket(requestsPerSecond, burst),
+		buckets:       make(map[string]*bucket),
+	}
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+	return l
+}
+
+// SetResourceLimit gives requests for the named resource (the part of the
+// path after the Account Sid or the API version, e.g. "Messages", "Calls" or
+// "Alerts") their own request rate. Requests for that resource no longer
+// count against the default rate, but do count against the concurrency
+// limit.
+func (l *Limiter) SetResourceLimit(resource string, requestsPerSecond float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buckets[resource] = newBucket(requestsPerSecond, burst)
+}
+
+// Wait blocks until a request for the given resource is allowed to proceed,
+// or until ctx is canceled. If Wait returns a nil error, the caller must call
+// release when the request completes.
+//
+// Wait returns an error right away if the request would not be allowed to
+// proceed before the ctx deadline.
+func (l *Limiter) Wait(ctx context.Context, resource string) (release func(), err error) {
+	l.mu.Lock()
+	b, ok := l.buckets[resource]
+	if !ok {
+		b = l.defaultBucket
+	}
+	l.mu.Unlock()
+	if b != nil {
+		if err := b.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if l.inFlight == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-l.inFlight })
+	}, nil
+}
+
+// SetLimiter configures c, and the Monitor and Pricing clients attached to it,
+// to wait for the given Limiter before making requests. Pass nil to remove
+// the limit.
+func (c *Client) SetLimiter(l *Limiter) {
+	c.Limiter = l
+	if c.Monitor != nil {
+		c.Monitor.Limiter = l
+	}
+	if c.Pricing != nil {
+		c.Pricing.Limiter = l
+	}
+}
+
+// wait blocks until c's Limiter (if any) lets a request for the given path
+// proceed.
+func (c *Client) wait(ctx context.Context, path string) (func(), error) {
+	if c.Limiter == nil {
+		return func() {}, nil
+	}
+	return c.Limiter.Wait(ctx, resourceName(path))
+}
+
+// resourceName returns the name of the top level resource in a request path,
+// for example "Messages" for
+// "/2010-04-01/Accounts/AC123/Messages/MM123/Media.json" or "Alerts" for
+// "/v1/Alerts/NO123".
+func resourceName(path string) string {
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	// drop the API version
+	parts = parts[1:]
+	if parts[0] == "Accounts" && len(parts) >= 3 {
+		parts = parts[2:]
+	}
+	return strings.TrimSuffix(parts[0], ".json")
+}
+
+// A bucket is a token bucket that refills at rate tokens per second, up to
+// burst tokens.
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newBucket returns a full bucket, or nil if rate is not positive.
+func newBucket(rate float64, burst int) *bucket {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token from the bucket, and returns how long the caller has
+// to wait before using it. The bucket may go into debt, so callers that are
+// waiting are served in order.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// unreserve returns a token that was reserved but not used.
+func (b *bucket) unreserve() {
+	b.mu.Lock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+	b.mu.Unlock()
+}
+
+func (b *bucket) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	delay := b.reserve(time.Now())
+	if delay == 0 {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		b.unreserve()
+		return context.DeadlineExceeded
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		b.unreserve()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,131 @@
+package twilio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func TestResourceName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/2010-04-01/Accounts/AC123/Messages.json", "Messages"},
+		{"/2010-04-01/Accounts/AC123/Messages.json?PageSize=5", "Messages"},
+		{"/2010-04-01/Accounts/AC123/Messages/MM123/Media/ME123", "Messages"},
+		{"/2010-04-01/Accounts/AC123/Calls/CA123.json", "Calls"},
+		{"/2010-04-01/Accounts.json", "Accounts"},
+		{"/2010-04-01/Accounts/AC123.json", "Accounts"},
+		{"/v1/Alerts", "Alerts"},
+		{"/v1/Alerts?PageSize=2&Page=1", "Alerts"},
+		{"/v1/Voice/Countries/US", "Voice"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := resourceName(tt.path); got != tt.want {
+			t.Errorf("resourceName(%q): got %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestLimiterRate(t *testing.T) {
+	t.Parallel()
+	l := NewLimiter(100, 1, 0)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := l.Wait(context.Background(), "Calls")
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	// the first request goes through right away, the next four wait 10ms
+	// each.
+	if since := time.Since(start); since < 35*time.Millisecond {
+		t.Errorf("expected 5 requests to take at least 40ms, took %v", since)
+	}
+}
+
+func TestLimiterResourceBuckets(t *testing.T) {
+	t.Parallel()
+	l := NewLimiter(1, 1, 0)
+	l.SetResourceLimit("Messages", 1000, 10)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	// drain the default bucket; Messages shouldn't be affected.
+	if _, err := l.Wait(ctx, "Calls"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := l.Wait(ctx, "Messages"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := l.Wait(ctx, "Calls"); err != context.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded waiting on an empty bucket, got %v", err)
+	}
+}
+
+func TestLimiterCanceled(t *testing.T) {
+	t.Parallel()
+	l := NewLimiter(0, 0, 1)
+	release, err := l.Wait(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := l.Wait(ctx, ""); err != context.Canceled {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+}
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	current, max := 0, 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		current++
+		if current > max {
+			max = current
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		current--
+		mu.Unlock()
+		w.Write(makeCallResponse)
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetLimiter(NewLimiter(0, 0, 2))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Calls.Get(context.Background(), "CA123"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if max > 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", max)
+	}
+	if client.Monitor.Limiter != client.Limiter {
+		t.Errorf("expected Monitor client to share the Limiter")
+	}
+}
@@ -0,0 +1,249 @@
+package twilio
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// A Logger receives an HTTPEvent for every request a Client sends and every
+// response it receives. Credentials are redacted from events before they are
+// passed to the Logger, so it's safe to write them to a log in production.
+//
+// LogHTTP is called synchronously, on the goroutine making the request, and
+// may be called from several goroutines at once.
+type Logger interface {
+	LogHTTP(ctx context.Context, e *HTTPEvent)
+}
+
+// An HTTPEvent describes a single HTTP request, or the response to one.
+type HTTPEvent struct {
+	// Response is false for an event describing a request, and true for an
+	// event describing its response (or the failure to get one).
+	Response bool
+	Method   string
+	URL      string
+	// Header contains the request or response headers. The Authorization
+	// header and cookies are redacted.
+	Header http.Header
+	// Body is only set if LogOptions.Bodies is true. Auth tokens and Key
+	// secrets are redacted.
+	Body string
+	// Truncated is true if Body was cut short at LogOptions.MaxBodySize.
+	Truncated bool
+
+	// The rest of the fields are only set on response events.
+
+	// StatusCode is 0 if no response was received.
+	StatusCode int
+	// The Twilio error code in the response, if any.
+	Code     Code
+	Duration time.Duration
+	Err      error
+	// Attempt is 1 for the first attempt at a request, 2 for the first retry,
+	// and so on.
+	Attempt int
+}
+
+// LogOptions control how much information is passed to a Logger.
+type LogOptions struct {
+	// Bodies controls whether request and response bodies are logged. If
+	// false, only the method, URL, headers and status are logged.
+	Bodies bool
+	// MaxBodySize is the maximum number of bytes of a body to log. Zero
+	// means no limit.
+	MaxBodySize int
+}
+
+// SetLogger configures c, and the Monitor and Pricing clients attached to it,
+// to send events for every HTTP request and response to l. If opts is nil,
+// only headers are logged. Pass a nil Logger to stop logging.
+//
+// If no Logger is set, you can set DEBUG_HTTP_TRAFFIC=true in your environment
+// to print every request and response to stderr (or DEBUG_HTTP_REQUEST=true
+// or DEBUG_HTTP_RESPONSES=true to print one or the other).
+func (c *Client) SetLogger(l Logger, opts *LogOptions) {
+	if opts == nil {
+		opts = new(LogOptions)
+	}
+	for _, client := range []*Client{c, c.Monitor, c.Pricing} {
+		if client != nil {
+			client.Logger = l
+			client.LogOptions = opts
+		}
+	}
+}
+
+// NewWriterLogger returns a Logger that writes events to w in a format
+// similar to a raw HTTP request or response.
+func NewWriterLogger(w io.Writer) Logger {
+	return &writerLogger{w: w, requests: true, responses: true}
+}
+
+type writerLogger struct {
+	mu                  sync.Mutex
+	w                   io.Writer
+	requests, responses bool
+}
+
+func (l *writerLogger) LogHTTP(ctx context.Context, e *HTTPEvent) {
+	if (e.Response && !l.responses) || (!e.Response && !l.requests) {
+		return
+	}
+	buf := new(bytes.Buffer)
+	if e.Response {
+		if e.StatusCode == 0 {
+			fmt.Fprintf(buf, "<-- %s %s (%v): %v\n", e.Method, e.URL, e.Duration, e.Err)
+		} else {
+			fmt.Fprintf(buf, "<-- %d %s %s (%v)\n", e.StatusCode, e.Method, e.URL, e.Duration)
+		}
+	} else {
+		fmt.Fprintf(buf, "--> %s %s\n", e.Method, e.URL)
+	}
+	keys := make([]string, 0, len(e.Header))
+	for k := range e.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range e.Header[k] {
+			fmt.Fprintf(buf, "%s: %s\n", k, v)
+		}
+	}
+	if e.Body != "" {
+		buf.WriteString("\n")
+		buf.WriteString(e.Body)
+		if e.Truncated {
+			buf.WriteString("...")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("\n")
+	l.mu.Lock()
+	l.w.Write(buf.Bytes())
+	l.mu.Unlock()
+}
+
+// activeLogger returns the Logger to use for c's requests, and the options to use
+// with it, or nil if nothing should be logged.
+func (c *Client) activeLogger() (Logger, *LogOptions) {
+	if c.Logger != nil {
+		opts := c.LogOptions
+		if opts == nil {
+			opts = new(LogOptions)
+		}
+		return c.Logger, opts
+	}
+	traffic := os.Getenv("DEBUG_HTTP_TRAFFIC") == "true"
+	requests := traffic || os.Getenv("DEBUG_HTTP_REQUEST") == "true"
+	responses := traffic || os.Getenv("DEBUG_HTTP_RESPONSES") == "true"
+	if !requests && !responses {
+		return nil, nil
+	}
+	return &writerLogger{w: os.Stderr, requests: requests, responses: responses}, &LogOptions{Bodies: true}
+}
+
+func (c *Client) logRequest(ctx context.Context, req *http.Request, body []byte) {
+	l, opts := c.activeLogger()
+	if l == nil {
+		return
+	}
+	e := &HTTPEvent{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: redactHeader(req.Header),
+	}
+	c.setEventBody(e, opts, body)
+	l.LogHTTP(ctx, e)
+}
+
+// logResponse logs the response to req. resp is nil if no response was
+// received, and body may be nil if it wasn't read.
+func (c *Client) logResponse(ctx context.Context, req *http.Request, resp *http.Response, body []byte, err error, start time.Time, attempt int) {
+	l, opts := c.activeLogger()
+	if l == nil {
+		return
+	}
+	e := &HTTPEvent{
+		Response: true,
+		Method:   req.Method,
+		URL:      req.URL.String(),
+		Duration: time.Since(start),
+		Err:      err,
+		Attempt:  attempt,
+	}
+	if resp != nil {
+		e.StatusCode = resp.StatusCode
+		e.Header = redactHeader(resp.Header)
+	}
+	if terr := asError(err); terr != nil {
+		e.Code = terr.Code
+	}
+	c.setEventBody(e, opts, body)
+	l.LogHTTP(ctx, e)
+}
+
+func (c *Client) setEventBody(e *HTTPEvent, opts *LogOptions, body []byte) {
+	if !opts.Bodies || len(body) == 0 {
+		return
+	}
+	s := c.redactBody(string(body))
+	if opts.MaxBodySize > 0 && len(s) > opts.MaxBodySize {
+		s = s[:opts.MaxBodySize]
+		e.Truncated = true
+	}
+	e.Body = s
+}
+
+const redacted = "[redacted]"
+
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
+
+// redactHeader returns a copy of h with credentials removed.
+func redactHeader(h http.Header) http.Header {
+	h2 := make(http.Header, len(h))
+	for k, v := range h {
+		h2[k] = append([]string(nil), v...)
+	}
+	for _, k := range sensitiveHeaders {
+		vals := h2[k]
+		for i, v := range vals {
+			if k == "Authorization" {
+				if idx := strings.IndexByte(v, ' '); idx > 0 {
+					vals[i] = v[:idx] + " " + redacted
+					continue
+				}
+			}
+			vals[i] = redacted
+		}
+	}
+	return h2
+}
+
+// Matches the auth_token in an Account, the secret in a Key and the SIP
+// password for a Call, in JSON or form encoded bodies.
+var secretJSONRx = regexp.MustCompile(`("(?:auth_token|secret)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+var secretFormRx = regexp.MustCompile(`\b((?:AuthToken|Secret|SipAuthPassword)=)[^&]*`)
+
+// redactBody removes auth tokens and Key secrets from a request or response
+// body.
+func (c *Client) redactBody(s string) string {
+	s = secretJSONRx.ReplaceAllString(s, `$1"`+redacted+`"`)
+	s = secretFormRx.ReplaceAllString(s, "$1"+redacted)
+	if c.Client != nil && len(c.Client.Token) >= 8 {
+		s = strings.Replace(s, c.Client.Token, redacted, -1)
+	}
+	if len(c.AuthToken) >= 8 {
+		s = strings.Replace(s, c.AuthToken, redacted, -1)
+	}
+	return s
+}
@@ -0,0 +1,134 @@
+package twilio
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []*HTTPEvent
+}
+
+func (r *eventRecorder) LogHTTP(ctx context.Context, e *HTTPEvent) {
+	r.mu.Lock()
+	r.events = append(r.events, e)
+	r.mu.Unlock()
+}
+
+var secretAccountResponse = []byte(`{"sid": "AC123", "auth_token": "0123456789abcdef", "friendly_name": "test"}`)
+
+func TestLoggerRedactsCredentials(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(secretAccountResponse)
+	defer s.Close()
+	r := new(eventRecorder)
+	client.SetLogger(r, &LogOptions{Bodies: true})
+	if _, err := client.Accounts.Get(context.Background(), "AC123"); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(r.events))
+	}
+	req, resp := r.events[0], r.events[1]
+	if req.Response || !resp.Response {
+		t.Errorf("expected a request event followed by a response event")
+	}
+	if auth := req.Header.Get("Authorization"); auth != "Basic [redacted]" {
+		t.Errorf("expected Authorization header to be redacted, got %q", auth)
+	}
+	if strings.Contains(resp.Body, "0123456789abcdef") || !strings.Contains(resp.Body, `"auth_token": "[redacted]"`) {
+		t.Errorf("expected auth_token to be redacted, got %q", resp.Body)
+	}
+	if resp.StatusCode != 200 || resp.Attempt != 1 || resp.Err != nil {
+		t.Errorf("bad response event: %#v", resp)
+	}
+	if client.Monitor.Logger != r {
+		t.Errorf("expected SetLogger to configure the Monitor client")
+	}
+}
+
+func TestLoggerHeadersOnly(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(secretAccountResponse)
+	defer s.Close()
+	r := new(eventRecorder)
+	client.SetLogger(r, nil)
+	if _, err := client.Accounts.Get(context.Background(), "AC123"); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range r.events {
+		if e.Body != "" {
+			t.Errorf("expected no body to be logged, got %q", e.Body)
+		}
+	}
+}
+
+func TestLoggerTruncates(t *testing.T) {
+	t.Parallel()
+	client, s := getServerCode([]byte(`{"code": 20404, "message": "The requested resource was not found", "status": 404}`), 404)
+	defer s.Close()
+	r := new(eventRecorder)
+	client.SetLogger(r, &LogOptions{Bodies: true, MaxBodySize: 10})
+	if _, err := client.Calls.Get(context.Background(), "CA123"); err == nil {
+		t.Fatal("expected non-nil error, got nil")
+	}
+	resp := r.events[len(r.events)-1]
+	if resp.Body != `{"code": 2` || !resp.Truncated {
+		t.Errorf("expected body to be truncated, got %q", resp.Body)
+	}
+	if resp.Code != CodeNotFound || resp.StatusCode != 404 || resp.Err == nil {
+		t.Errorf("bad response event: %#v", resp)
+	}
+}
+
+func TestWriterLogger(t *testing.T) {
+	t.Parallel()
+	buf := new(bytes.Buffer)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"sid": "SK123", "secret": "shhh"}`))
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetLogger(NewWriterLogger(buf), &LogOptions{Bodies: true})
+	if _, err := client.Keys.Get(context.Background(), "SK123"); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "--> GET "+s.URL+"/2010-04-01/Accounts/AC123/Keys/SK123.json") {
+		t.Errorf("expected request line in output, got %q", out)
+	}
+	if !strings.Contains(out, "<-- 200 GET") {
+		t.Errorf("expected response line in output, got %q", out)
+	}
+	if strings.Contains(out, "shhh") {
+		t.Errorf("expected Key secret to be redacted, got %q", out)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	t.Parallel()
+	c := NewClient("AC123", "supersecrettoken", nil)
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`{"secret": "abc\"def"}`, `{"secret": "[redacted]"}`},
+		{`FriendlyName=foo&AuthToken=xyz`, `FriendlyName=foo&AuthToken=[redacted]`},
+		{`To=sip%3Aalice%40example.com&SipAuthPassword=hunter2&SipAuthUsername=alice`, `To=sip%3Aalice%40example.com&SipAuthPassword=[redacted]&SipAuthUsername=alice`},
+		{`token is supersecrettoken`, `token is [redacted]`},
+		{`{"sid": "AC123"}`, `{"sid": "AC123"}`},
+	}
+	for _, tt := range tests {
+		if got := c.redactBody(tt.in); got != tt.want {
+			t.Errorf("redactBody(%q): got %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
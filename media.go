@@ -11,10 +11,10 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
-	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/net/context"
 )
@@ -22,6 +22,9 @@ import (
 // A MediaService lets you retrieve a message's associated Media.
 type MediaService struct {
 	client *Client
+	// MaxSize is the largest Media, in bytes, that Download and the methods
+	// built on it will return. If zero, DefaultMaxMediaSize is used.
+	MaxSize int64
 }
 
 func mediaPathPart(messageSid string) string {
@@ -56,7 +59,17 @@ func (m *MediaService) Get(ctx context.Context, messageSid string, sid string) (
 	return me, err
 }
 
-// GetURL returns a URL that can be retrieved to download the given image.
+// Delete the Media with the given sid from the message with messageSid. If the
+// Media has already been deleted, or does not exist, Delete returns nil. If
+// another error or a timeout occurs, the error is returned.
+func (m *MediaService) Delete(ctx context.Context, messageSid string, sid string) error {
+	return m.client.DeleteResource(ctx, mediaPathPart(messageSid), sid)
+}
+
+// GetURL returns a URL that can be retrieved to download the given media. It
+// follows the redirects from the Twilio API according to the Client's
+// MediaResolver, and returns a *MediaResolveError if it can't find an allowed
+// URL.
 func (m *MediaService) GetURL(ctx context.Context, messageSid string, sid string) (*url.URL, error) {
 	uriEnd := strings.Join([]string{mediaPathPart(messageSid), sid}, "/")
 	path := m.client.FullPath(uriEnd)
@@ -65,73 +78,55 @@ func (m *MediaService) GetURL(ctx context.Context, messageSid string, sid string
 		path = path[:len(path)-len(".json")]
 	}
 	urlStr := m.client.Client.Base + path
-	count := 0
+	release, err := m.client.wait(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	resolver := m.client.MediaResolver
+	if resolver == nil {
+		resolver = DefaultMediaResolver
+	}
+	redirects := 0
 	for {
 		req, err := http.NewRequest("GET", urlStr, nil)
 		if err != nil {
 			return nil, err
 		}
 		req = withContext(req, ctx)
-		req.SetBasicAuth(m.client.AccountSid, m.client.AuthToken)
+		// Use the credentials from the rest client; AccountSid may be a
+		// subaccount.
+		req.SetBasicAuth(m.client.Client.ID, m.client.Client.Token)
 		req.Header.Set("User-Agent", userAgent)
-		if os.Getenv("DEBUG_HTTP_TRAFFIC") == "true" || os.Getenv("DEBUG_HTTP_REQUEST") == "true" {
-			b := new(bytes.Buffer)
-			bits, _ := httputil.DumpRequestOut(req, true)
-			if len(bits) > 0 && bits[len(bits)-1] != '\n' {
-				bits = append(bits, '\n')
-			}
-			b.Write(bits)
-			io.Copy(os.Stderr, b)
-		}
+		m.client.beforeRequest(ctx, req)
+		m.client.logRequest(ctx, req, nil)
+		start := time.Now()
 		resp, err := MediaClient.Do(req)
 		if err != nil {
+			m.client.logResponse(ctx, req, nil, nil, err, start, 1)
+			m.client.afterResponse(ctx, req, nil, err, start, 1)
 			return nil, err
 		}
-		if os.Getenv("DEBUG_HTTP_TRAFFIC") == "true" || os.Getenv("DEBUG_HTTP_RESPONSES") == "true" {
-			b := new(bytes.Buffer)
-			bits, _ := httputil.DumpResponse(resp, true)
-			if len(bits) > 0 && bits[len(bits)-1] != '\n' {
-				bits = append(bits, '\n')
-			}
-			b.Write(bits)
-			io.Copy(os.Stderr, b)
-		} else {
-			io.Copy(ioutil.Discard, resp.Body)
-		}
-
+		body, err := ioutil.ReadAll(resp.Body)
 		resp.Body.Close()
-		// This is brittle because we need to detect/rewrite the S3 URL.
-		// I don't want to hard code a S3 URL but we have to do some
-		// substitution.
-		location := resp.Header.Get("Location")
-		if location == "" {
-			return nil, errors.New("twilio: Couldn't follow redirect")
+		if err == nil && resp.StatusCode >= 400 {
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+			err = parseTwilioError(resp)
 		}
-		u, err := url.Parse(location)
+		m.client.logResponse(ctx, req, resp, body, err, start, 1)
+		m.client.afterResponse(ctx, req, resp, err, start, 1)
 		if err != nil {
 			return nil, err
 		}
-		if strings.Contains(u.Host, "amazonaws") && strings.Count(u.Host, ".") == 2 && u.Scheme == "https" {
-			return u, nil
+		u, done, err := resolver.resolve(urlStr, resp.Header.Get("Location"), redirects)
+		if err != nil {
+			return nil, err
 		}
-		if strings.Contains(u.Host, "media.twiliocdn.com.") && strings.Contains(u.Host, "amazonaws") {
-			// This is the URL we can use to download the content. The URL that
-			// Twilio gives us back is insecure and uses HTTP. Rewrite it to
-			// use the HTTPS path-based URL scheme.
-			//
-			// https://docs.aws.amazon.com/AmazonS3/latest/dev/UsingBucket.html
-			if u.Scheme == "http" {
-				u.Host = strings.Replace(u.Host, "media.twiliocdn.com.", "", 1)
-				u.Path = "/media.twiliocdn.com" + u.Path
-				u.Scheme = "https"
-			}
+		if done {
 			return u, nil
 		}
-		count++
-		if count > 5 {
-			return nil, errors.New("twilio: too many redirects")
-		}
-		urlStr = location
+		redirects++
+		urlStr = u.String()
 	}
 }
 
@@ -140,35 +135,197 @@ func (m *MediaService) GetURL(ctx context.Context, messageSid string, sid string
 // request to the Twilio API, then to media.twiliocdn.com, then to a S3 URL. We
 // then download that image and decode it based on the provided content-type.
 func (m *MediaService) GetImage(ctx context.Context, messageSid string, sid string) (image.Image, error) {
-	u, err := m.GetURL(ctx, messageSid, sid)
+	body, ctype, err := m.Download(ctx, messageSid, sid)
 	if err != nil {
 		return nil, err
 	}
+	defer body.Close()
+	return decodeImage(ctype, body)
+}
+
+func decodeImage(ctype string, r io.Reader) (image.Image, error) {
+	// https://www.twilio.com/docs/api/rest/accepted-mime-types#supported
+	switch ctype {
+	case "image/jpeg":
+		return jpeg.Decode(r)
+	case "image/gif":
+		return gif.Decode(r)
+	case "image/png":
+		return png.Decode(r)
+	default:
+		return nil, fmt.Errorf("twilio: Unknown content-type %s", ctype)
+	}
+}
+
+// DefaultMaxMediaSize is the largest Media that Download will return, unless
+// MediaService.MaxSize is set. It's larger than any file Twilio accepts.
+const DefaultMaxMediaSize = 32 * 1024 * 1024
+
+// ErrMediaTooLarge is returned when Media is larger than MediaService.MaxSize.
+var ErrMediaTooLarge = errors.New("twilio: Media is larger than the maximum size")
+
+// Download retrieves a Media object and returns its content and its
+// Content-Type, which may be any type Twilio accepts - audio, video, vCard,
+// PDF and so on. The caller must close the body.
+//
+// If the Media is larger than m.MaxSize, Download returns ErrMediaTooLarge,
+// or, if the size isn't known in advance, reading the body does.
+func (m *MediaService) Download(ctx context.Context, messageSid string, sid string) (io.ReadCloser, string, error) {
+	u, err := m.GetURL(ctx, messageSid, sid)
+	if err != nil {
+		return nil, "", err
+	}
 	if u.Scheme == "http" {
-		return nil, fmt.Errorf("Attempted to download image over insecure URL: %s", u.String())
+		return nil, "", fmt.Errorf("Attempted to download media over insecure URL: %s", u.String())
 	}
-	req, err := http.NewRequest("GET", u.String(), nil)
+	return m.download(ctx, u)
+}
+
+// DownloadTo writes the content of a Media object to w, and returns its
+// Content-Type. See Download.
+func (m *MediaService) DownloadTo(ctx context.Context, messageSid string, sid string, w io.Writer) (string, error) {
+	body, ctype, err := m.Download(ctx, messageSid, sid)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	_, err = io.Copy(w, body)
+	return ctype, err
+}
+
+// MediaContent is a downloaded Media object.
+type MediaContent struct {
+	Sid         string
+	ContentType string
+	Data        []byte
+}
+
+// DownloadAll downloads every Media object attached to the given message at
+// the same time, and returns them in the order Twilio lists them. If any
+// download fails, the rest are canceled and the first error is returned.
+func (m *MediaService) DownloadAll(ctx context.Context, messageSid string) ([]*MediaContent, error) {
+	page, err := m.GetPage(ctx, messageSid, nil)
 	if err != nil {
 		return nil, err
 	}
+	contents := make([]*MediaContent, len(page.MediaList))
+	err = eachMedia(ctx, page.MediaList, func(ctx context.Context, i int, media *Media) error {
+		body, ctype, err := m.Download(ctx, messageSid, media.Sid)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		contents[i] = &MediaContent{Sid: media.Sid, ContentType: ctype, Data: data}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
+
+// eachMedia calls fn for each item in list concurrently. If fn returns an
+// error, the Context passed to the other calls is canceled, and the error is
+// returned once they all return.
+func eachMedia(ctx context.Context, list []*Media, fn func(context.Context, int, *Media) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var mu sync.Mutex
+	var firstErr error
+	var wg sync.WaitGroup
+	wg.Add(len(list))
+	for i, media := range list {
+		go func(i int, media *Media) {
+			defer wg.Done()
+			if err := fn(ctx, i, media); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}(i, media)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func (m *MediaService) maxSize() int64 {
+	if m.MaxSize > 0 {
+		return m.MaxSize
+	}
+	return DefaultMaxMediaSize
+}
+
+// download retrieves the content at u, which should be the URL returned by
+// GetURL.
+func (m *MediaService) download(ctx context.Context, u *url.URL) (io.ReadCloser, string, error) {
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
 	req = withContext(req, ctx)
 	req.Header.Set("User-Agent", userAgent)
+	m.client.beforeRequest(ctx, req)
+	m.client.logRequest(ctx, req, nil)
+	start := time.Now()
 	resp, err := MediaClient.Do(req)
 	if err != nil {
-		return nil, err
+		m.client.logResponse(ctx, req, nil, nil, err, start, 1)
+		m.client.afterResponse(ctx, req, nil, err, start, 1)
+		return nil, "", err
 	}
-	defer resp.Body.Close()
-	// https://www.twilio.com/docs/api/rest/accepted-mime-types#supported
-	ctype := resp.Header.Get("Content-Type")
-	switch ctype {
-	case "image/jpeg":
-		return jpeg.Decode(resp.Body)
-	case "image/gif":
-		return gif.Decode(resp.Body)
-	case "image/png":
-		return png.Decode(resp.Body)
-	default:
-		io.Copy(ioutil.Discard, resp.Body)
-		return nil, fmt.Errorf("twilio: Unknown content-type %s", ctype)
+	max := m.maxSize()
+	switch {
+	case resp.StatusCode >= 400:
+		// Cap the error body, but keep the original Closer so the connection
+		// is released.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(resp.Body, 64*1024), resp.Body}
+		err = parseTwilioError(resp)
+	case resp.ContentLength > max:
+		err = ErrMediaTooLarge
+	}
+	// Don't log the media content.
+	m.client.logResponse(ctx, req, resp, nil, err, start, 1)
+	m.client.afterResponse(ctx, req, resp, err, start, 1)
+	if err != nil {
+		resp.Body.Close()
+		return nil, "", err
+	}
+	return &limitedBody{rc: resp.Body, remaining: max}, resp.Header.Get("Content-Type"), nil
+}
+
+// limitedBody returns ErrMediaTooLarge if more than remaining bytes are read
+// from rc.
+type limitedBody struct {
+	rc        io.ReadCloser
+	remaining int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, ErrMediaTooLarge
+	}
+	// read one byte past the limit, so we can tell if the body is too long.
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.rc.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), ErrMediaTooLarge
 	}
+	return n, err
+}
+
+func (l *limitedBody) Close() error {
+	return l.rc.Close()
 }
@@ -1,8 +1,14 @@
 package twilio
 
 import (
+	"bytes"
 	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"path"
 	"strings"
 	"testing"
 	"time"
@@ -54,3 +60,110 @@ func TestGetImage(t *testing.T) {
 		t.Errorf("Invalid picture bounds: %v", bounds)
 	}
 }
+
+// mediaServer serves the Twilio media endpoints and the S3 URLs they
+// redirect to, and routes MediaClient's requests to itself. Tests using it
+// can't run in parallel.
+func mediaServer(t *testing.T) (*Client, func()) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2010-04-01/Accounts/AC123/Messages/MM123/Media.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"media_list": [{"sid": "ME1"}, {"sid": "ME2"}, {"sid": "ME3"}]}`))
+	})
+	mux.HandleFunc("/2010-04-01/Accounts/AC123/Messages/MM123/Media/", func(w http.ResponseWriter, r *http.Request) {
+		sid := path.Base(r.URL.Path)
+		w.Header().Set("Location", "https://s3-external-1.amazonaws.com/media.twiliocdn.com/AC123/"+sid)
+		w.WriteHeader(302)
+	})
+	mux.HandleFunc("/media.twiliocdn.com/AC123/", func(w http.ResponseWriter, r *http.Request) {
+		switch path.Base(r.URL.Path) {
+		case "ME1":
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.4"))
+		case "ME2":
+			// no Content-Length
+			w.Header().Set("Content-Type", "text/vcard")
+			w.Write([]byte("BEGIN:"))
+			w.(http.Flusher).Flush()
+			w.Write([]byte("VCARD"))
+		case "ME3":
+			// slow, until the request is canceled
+			select {
+			case <-w.(http.CloseNotifier).CloseNotify():
+			case <-time.After(5 * time.Second):
+				t.Error("download wasn't canceled")
+			}
+		default:
+			w.WriteHeader(404)
+		}
+	})
+	s := httptest.NewServer(mux)
+	u, _ := url.Parse(s.URL)
+	MediaClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		r.URL.Scheme = "http"
+		r.URL.Host = u.Host
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	return client, func() {
+		MediaClient.Transport = nil
+		s.Close()
+	}
+}
+
+func TestDownload(t *testing.T) {
+	client, done := mediaServer(t)
+	defer done()
+	ctx := context.Background()
+	buf := new(bytes.Buffer)
+	ctype, err := client.Media.DownloadTo(ctx, "MM123", "ME1", buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctype != "application/pdf" || buf.String() != "%PDF-1.4" {
+		t.Errorf("got %q %q, want a PDF", ctype, buf.String())
+	}
+	client.Media.MaxSize = 4
+	if _, _, err := client.Media.Download(ctx, "MM123", "ME1"); err != ErrMediaTooLarge {
+		t.Errorf("expected ErrMediaTooLarge, got %v", err)
+	}
+	body, _, err := client.Media.Download(ctx, "MM123", "ME2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	if _, err := ioutil.ReadAll(body); err != ErrMediaTooLarge {
+		t.Errorf("expected ErrMediaTooLarge reading the body, got %v", err)
+	}
+}
+
+func TestDownloadAllCancels(t *testing.T) {
+	client, done := mediaServer(t)
+	defer done()
+	client.Media.MaxSize = 4
+	start := time.Now()
+	_, err := client.Media.DownloadAll(context.Background(), "MM123")
+	if err != ErrMediaTooLarge {
+		t.Errorf("expected ErrMediaTooLarge, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("DownloadAll didn't cancel the slow download, took %v", elapsed)
+	}
+}
+
+// Swaps MediaClient.Transport, so can't run in parallel.
+func TestDownloadClosesMediaErrorBody(t *testing.T) {
+	body := &trackedBody{Reader: strings.NewReader(`{"code": 20404, "message": "Not found", "status": 404}`)}
+	MediaClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return errorResponse(r, body), nil
+	})
+	defer func() { MediaClient.Transport = nil }()
+	client := NewClient("AC123", "456", nil)
+	u, _ := url.Parse("https://s3-external-1.amazonaws.com/media.twiliocdn.com/AC123/ME1")
+	if _, _, err := client.Media.download(context.Background(), u); !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if !body.closed {
+		t.Errorf("expected the response body to be closed")
+	}
+}
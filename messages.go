@@ -3,7 +3,6 @@ package twilio
 import (
 	"fmt"
 	"net/url"
-	"sync"
 	"time"
 
 	types "github.com/kevinburke/go-types"
@@ -73,6 +72,31 @@ type Message struct {
 	ErrorMessage        string            `json:"error_message"`
 }
 
+// Ended returns true if the Message has reached a terminal state, and false
+// otherwise, or if the state can't be determined. A "sent" message isn't
+// considered ended, since it may still be delivered or undelivered, though
+// some carriers never report either.
+func (m *Message) Ended() bool {
+	// https://www.twilio.com/docs/api/rest/message#message-status-values
+	switch m.Status {
+	case StatusDelivered, StatusUndelivered, StatusFailed, StatusReceived, StatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// EndedUnsuccessfully returns true if the Message has reached a terminal
+// state and that state isn't "delivered" or "received".
+func (m *Message) EndedUnsuccessfully() bool {
+	switch m.Status {
+	case StatusUndelivered, StatusFailed, StatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
 // FriendlyPrice flips the sign of the Price (which is usually reported from
 // the API as a negative number) and adds an appropriate currency symbol in
 // front of it. For example, a PriceUnit of "USD" and a Price of "-1.25" is
@@ -96,8 +120,25 @@ func (m *MessageService) Create(ctx context.Context, data url.Values) (*Message,
 	return msg, err
 }
 
+// CreateWithParams validates p and creates a message with it. If p is not
+// valid, a *ValidationError is returned and no request is made.
+func (m *MessageService) CreateWithParams(ctx context.Context, p *MessageParams) (*Message, error) {
+	data, err := p.Values()
+	if err != nil {
+		return nil, err
+	}
+	return m.Create(ctx, data)
+}
+
 // SendMessage is a convenience wrapper around Create.
+//
+// Deprecated: Use SendMessageContext, which accepts a Context.
 func (m *MessageService) SendMessage(from string, to string, body string, mediaURLs []*url.URL) (*Message, error) {
+	return m.SendMessageContext(context.Background(), from, to, body, mediaURLs)
+}
+
+// SendMessageContext is a convenience wrapper around Create.
+func (m *MessageService) SendMessageContext(ctx context.Context, from string, to string, body string, mediaURLs []*url.URL) (*Message, error) {
 	v := url.Values{
 		"Body": []string{body},
 		"From": []string{from},
@@ -108,10 +149,11 @@ func (m *MessageService) SendMessage(from string, to string, body string, mediaU
 			v.Add("MediaUrl", mediaURL.String())
 		}
 	}
-	return m.Create(context.Background(), v)
+	return m.Create(ctx, v)
 }
 
-// MessagePageIterator lets you retrieve consecutive pages of resources.
+// MessagePageIterator lets you retrieve consecutive pages of resources. The
+// iterators returned by MessageService also implement Prefetcher.
 type MessagePageIterator interface {
 	// Next returns the next page of resources. If there are no more resources,
 	// NoMoreResults is returned.
@@ -122,6 +164,24 @@ type messagePageIterator struct {
 	p *PageIterator
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (m *messagePageIterator) Prefetch(depth int) {
+	m.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (m *messagePageIterator) Stop() {
+	m.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (m *messagePageIterator) Cursor() Cursor {
+	return m.p.Cursor()
+}
+
 // Next returns the next page of resources. If there are no more resources,
 // NoMoreResults is returned.
 func (m *messagePageIterator) Next(ctx context.Context) (*MessagePage, error) {
@@ -142,12 +202,132 @@ func (m *MessageService) GetPageIterator(data url.Values) MessagePageIterator {
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator. If cur came from a GetMessagesInRange iterator, the
+// returned iterator only returns results in the same range.
+func (m *MessageService) ResumePageIterator(cur Cursor) MessagePageIterator {
+	iter := ResumePageIterator(m.client, messagesPathPart, cur)
+	if cur.inRange() {
+		return &messageDateIterator{
+			start: cur.Start,
+			end:   cur.End,
+			p:     iter,
+		}
+	}
+	return &messagePageIterator{
+		p: iter,
+	}
+}
+
 func (m *MessageService) Get(ctx context.Context, sid string) (*Message, error) {
 	msg := new(Message)
 	err := m.client.GetResource(ctx, messagesPathPart, sid, msg)
 	return msg, err
 }
 
+// Update the message with the given data. Valid parameters may be found here:
+// https://www.twilio.com/docs/api/rest/message#instance-post
+func (m *MessageService) Update(ctx context.Context, sid string, data url.Values) (*Message, error) {
+	msg := new(Message)
+	err := m.client.UpdateResource(ctx, messagesPathPart, sid, data, msg)
+	return msg, err
+}
+
+// Redact blanks the Body of the message with the given sid, keeping the rest
+// of the record. Twilio only lets you redact messages that have finished
+// sending.
+func (m *MessageService) Redact(ctx context.Context, sid string) (*Message, error) {
+	data := url.Values{}
+	data.Set("Body", "")
+	return m.Update(ctx, sid, data)
+}
+
+// Delete the Message with the given sid. If the Message has already been
+// deleted, or does not exist, Delete returns nil. If another error or a
+// timeout occurs, the error is returned. Deleting a Message does not delete
+// its Media; use Client.Media.Delete, or DeleteInRange, which deletes both.
+func (m *MessageService) Delete(ctx context.Context, sid string) error {
+	return m.client.DeleteResource(ctx, messagesPathPart, sid)
+}
+
+// A DeleteFailure records a resource that DeleteInRange couldn't delete.
+type DeleteFailure struct {
+	MessageSid string
+	// MediaSid is empty if the Message itself couldn't be deleted, or its
+	// Media couldn't be listed.
+	MediaSid string
+	Err      error
+}
+
+// DeleteReport is the result of DeleteInRange.
+type DeleteReport struct {
+	// Sids of the Messages that were deleted, along with all of their Media.
+	Deleted []string
+	// MediaDeleted is the number of Media resources deleted.
+	MediaDeleted int
+	Failed       []*DeleteFailure
+}
+
+// DeleteInRange deletes the messages sent in the range [start, end) that
+// match the filters in data, along with their Media. start, end and data are
+// interpreted as in GetMessagesInRange.
+//
+// A failure to delete a message or one of its Media is recorded in the
+// report's Failed list, and DeleteInRange moves on to the next message; a
+// message is only deleted once all of its Media are. An error is returned if
+// a page of messages can't be retrieved, or ctx is canceled, along with a
+// report of the work done so far.
+func (m *MessageService) DeleteInRange(ctx context.Context, start time.Time, end time.Time, data url.Values) (*DeleteReport, error) {
+	report := new(DeleteReport)
+	iter := m.GetMessagesInRange(start, end, data)
+	for {
+		page, err := iter.Next(ctx)
+		if err == NoMoreResults {
+			return report, nil
+		}
+		if err != nil {
+			return report, err
+		}
+		for _, msg := range page.Messages {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+			if m.deleteWithMedia(ctx, msg, report) {
+				report.Deleted = append(report.Deleted, msg.Sid)
+			}
+		}
+	}
+}
+
+// deleteWithMedia deletes msg and its Media, recording any failures in
+// report, and returns true if msg was deleted.
+func (m *MessageService) deleteWithMedia(ctx context.Context, msg *Message, report *DeleteReport) bool {
+	if msg.NumMedia > 0 {
+		page, err := m.client.Media.GetPage(ctx, msg.Sid, nil)
+		if err != nil {
+			report.Failed = append(report.Failed, &DeleteFailure{MessageSid: msg.Sid, Err: err})
+			return false
+		}
+		ok := true
+		for _, media := range page.MediaList {
+			if err := m.client.Media.Delete(ctx, msg.Sid, media.Sid); err != nil {
+				report.Failed = append(report.Failed, &DeleteFailure{MessageSid: msg.Sid, MediaSid: media.Sid, Err: err})
+				ok = false
+				continue
+			}
+			report.MediaDeleted++
+		}
+		if !ok {
+			return false
+		}
+	}
+	if err := m.Delete(ctx, msg.Sid); err != nil {
+		report.Failed = append(report.Failed, &DeleteFailure{MessageSid: msg.Sid, Err: err})
+		return false
+	}
+	return true
+}
+
 // GetPage returns a single page of resources. To retrieve multiple pages, use
 // GetPageIterator.
 func (m *MessageService) GetPage(ctx context.Context, data url.Values) (*MessagePage, error) {
@@ -227,54 +407,61 @@ type messageDateIterator struct {
 	end   time.Time
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *messageDateIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *messageDateIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator, including the range bounds.
+func (c *messageDateIterator) Cursor() Cursor {
+	cur := c.p.Cursor()
+	cur.Start = c.start
+	cur.End = c.end
+	return cur
+}
+
 // Next returns the next page of resources. We may need to fetch multiple
 // pages from the Twilio API before we find one in the right date range, so
 // latency may be higher than usual.
 func (c *messageDateIterator) Next(ctx context.Context) (*MessagePage, error) {
-	var page *MessagePage
-	for {
-		// just wipe it clean every time to avoid remnants hanging around
-		page = new(MessagePage)
-		if err := c.p.Next(ctx, page); err != nil {
-			return nil, err
-		}
-		if len(page.Messages) == 0 {
-			return nil, NoMoreResults
-		}
-		times := make([]time.Time, len(page.Messages), len(page.Messages))
-		for i, message := range page.Messages {
-
-			if !message.DateCreated.Valid {
-				// we really should not ever hit this case but if we can't parse
-				// a date, better to give you back an error than to give you back
-				// a list of messages that may or may not be in the time range
-				return nil, fmt.Errorf("Couldn't verify the date of message: %#v", message)
-			}
-			times[i] = message.DateCreated.Time
-		}
-		if containsResultsInRange(c.start, c.end, times) {
-			indexesToDelete := indexesOutsideRange(c.start, c.end, times)
-			// reverse order so we don't delete the wrong index
-			for i := len(indexesToDelete) - 1; i >= 0; i-- {
-				index := indexesToDelete[i]
-				page.Messages = append(page.Messages[:index], page.Messages[index+1:]...)
-			}
-			c.p.SetNextPageURI(page.NextPageURI)
-			return page, nil
-		}
-		if shouldContinuePaging(c.start, times) {
-			c.p.SetNextPageURI(page.NextPageURI)
-			continue
-		} else {
-			// should not continue paging and no results in range, stop
-			return nil, NoMoreResults
+	page, err := nextPageInRange(ctx, c.p, c.start, c.end, true, func() datedPage { return new(MessagePage) })
+	if err != nil {
+		return nil, err
+	}
+	return page.(*MessagePage), nil
+}
+
+func (p *MessagePage) dates() ([]time.Time, error) {
+	times := make([]time.Time, len(p.Messages))
+	for i, message := range p.Messages {
+		if !message.DateCreated.Valid {
+			// we really should not ever hit this case but if we can't parse
+			// a date, better to give you back an error than to give you back
+			// a list of messages that may or may not be in the time range
+			return nil, fmt.Errorf("Couldn't verify the date of message: %#v", message)
 		}
+		times[i] = message.DateCreated.Time
+	}
+	return times, nil
+}
+
+func (p *MessagePage) removeIndexes(indexes []int) {
+	// reverse order so we don't delete the wrong index
+	for i := len(indexes) - 1; i >= 0; i-- {
+		p.Messages = append(p.Messages[:indexes[i]], p.Messages[indexes[i]+1:]...)
 	}
 }
 
 // GetMediaURLs gets the URLs of any media for this message. This uses threads
-// to retrieve all URLs simultaneously; if retrieving any URL fails, we return
-// an error for the entire request.
+// to retrieve all URLs simultaneously; if retrieving any URL fails, we cancel
+// the others and return an error for the entire request.
 //
 // The data can be used to filter the list of returned Media as described here:
 // https://www.twilio.com/docs/api/rest/media#list-get-filters
@@ -292,23 +479,82 @@ func (m *MessageService) GetMediaURLs(ctx context.Context, sid string, data url.
 		return urls, nil
 	}
 	urls := make([]*url.URL, len(page.MediaList))
-	errs := make([]error, len(page.MediaList))
-	var wg sync.WaitGroup
-	wg.Add(len(page.MediaList))
-	for i, media := range page.MediaList {
-		go func(i int, media *Media) {
-			url, err := m.client.Media.GetURL(ctx, sid, media.Sid)
-			urls[i] = url
-			errs[i] = err
-			wg.Done()
-		}(i, media)
+	err = eachMedia(ctx, page.MediaList, func(ctx context.Context, i int, media *Media) error {
+		u, err := m.client.Media.GetURL(ctx, sid, media.Sid)
+		urls[i] = u
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
-	wg.Wait()
-	// todo - we could probably return more quickly in the result of a failure.
-	for _, err := range errs {
+	return urls, nil
+}
+
+// MessageIterator yields Messages one at a time. Call Next to advance the
+// iterator, and Value to get the current Message.
+type MessageIterator struct {
+	*ItemIterator
+}
+
+// NewMessageIterator returns a MessageIterator that yields each Message in the
+// pages returned by p.
+func NewMessageIterator(p MessagePageIterator) *MessageIterator {
+	return &MessageIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
 		if err != nil {
 			return nil, err
 		}
-	}
-	return urls, nil
+		items := make([]interface{}, len(page.Messages))
+		for i := range page.Messages {
+			items[i] = page.Messages[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current Message, or nil if Next hasn't been called or
+// returned false.
+func (m *MessageIterator) Value() *Message {
+	v, _ := m.ItemIterator.Value().(*Message)
+	return v
+}
+
+// GetIterator returns an iterator over the Messages matching the filters in
+// data, fetching new pages as needed.
+func (m *MessageService) GetIterator(data url.Values) *MessageIterator {
+	return NewMessageIterator(m.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (m *MessageService) ResumeIterator(cur Cursor) *MessageIterator {
+	iter := NewMessageIterator(m.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
+
+// Stream sends the messages matching the filters in data to the returned channel,
+// fetching new pages as needed. If the caller doesn't keep up, Stream waits
+// for it before fetching more pages. The messages channel is closed when there
+// are no more messages, an error occurs, or ctx is cancelled; then the error
+// channel receives the error (or nil) and is closed. To stop early, cancel
+// ctx.
+func (m *MessageService) Stream(ctx context.Context, data url.Values) (<-chan *Message, <-chan error) {
+	messages := make(chan *Message, streamBufferSize)
+	errs := make(chan error, 1)
+	iter := m.GetIterator(data)
+	go func() {
+		err := iter.stream(ctx, func(v interface{}) bool {
+			select {
+			case messages <- v.(*Message):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		close(messages)
+		errs <- err
+		close(errs)
+	}()
+	return messages, errs
 }
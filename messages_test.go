@@ -3,9 +3,12 @@ package twilio
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -184,3 +187,83 @@ func TestStatusFriendly(t *testing.T) {
 		t.Errorf("expected In Progress.Friendly to equal In Progress, got %s", f)
 	}
 }
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(sendMessageResponse)
+	defer s.Close()
+	var body string
+	s.s.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		body = r.Form.Encode()
+		w.Write(sendMessageResponse)
+	})
+	if _, err := client.Messages.Redact(context.Background(), "SM123"); err != nil {
+		t.Fatal(err)
+	}
+	if body != "Body=" {
+		t.Errorf("expected an empty Body, got %q", body)
+	}
+}
+
+func TestDeleteInRange(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var deleted []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/2010-04-01/Accounts/AC123/")
+		if r.Method == "DELETE" {
+			if path == "Messages/SM3/Media/ME2.json" {
+				w.WriteHeader(403)
+				w.Write([]byte(`{"code": 20003, "message": "Permission denied", "status": 403}`))
+				return
+			}
+			mu.Lock()
+			deleted = append(deleted, path)
+			mu.Unlock()
+			w.WriteHeader(204)
+			return
+		}
+		switch path {
+		case "Messages.json":
+			w.Write([]byte(`{"next_page_uri": null, "messages": [
+{"sid": "SM1", "num_media": "0", "date_created": "Tue, 14 Feb 2017 10:00:00 +0000", "date_sent": "Tue, 14 Feb 2017 10:00:00 +0000"},
+{"sid": "SM2", "num_media": "1", "date_created": "Mon, 13 Feb 2017 10:00:00 +0000", "date_sent": "Mon, 13 Feb 2017 10:00:00 +0000"},
+{"sid": "SM3", "num_media": "2", "date_created": "Mon, 13 Feb 2017 09:00:00 +0000", "date_sent": "Mon, 13 Feb 2017 09:00:00 +0000"},
+{"sid": "SM4", "num_media": "0", "date_created": "Sun, 12 Feb 2017 09:00:00 +0000", "date_sent": "Sun, 12 Feb 2017 09:00:00 +0000"}]}`))
+		case "Messages/SM2/Media.json":
+			w.Write([]byte(`{"media_list": [{"sid": "ME1"}]}`))
+		case "Messages/SM3/Media.json":
+			w.Write([]byte(`{"media_list": [{"sid": "ME2"}, {"sid": "ME3"}]}`))
+		default:
+			w.WriteHeader(404)
+			w.Write([]byte(`{"code": 20404, "message": "Not found", "status": 404}`))
+		}
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	start := time.Date(2017, 2, 13, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2017, 2, 14, 0, 0, 0, 0, time.UTC)
+	report, err := client.Messages.DeleteInRange(context.Background(), start, end, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(report.Deleted) != "[SM2]" {
+		t.Errorf("expected SM2 to be deleted, got %v", report.Deleted)
+	}
+	if report.MediaDeleted != 2 {
+		t.Errorf("expected 2 media deleted, got %d", report.MediaDeleted)
+	}
+	if len(report.Failed) != 1 {
+		t.Fatalf("expected one failure, got %v", report.Failed)
+	}
+	if f := report.Failed[0]; f.MessageSid != "SM3" || f.MediaSid != "ME2" || f.Err == nil {
+		t.Errorf("bad failure: %#v", f)
+	}
+	want := "[Messages/SM2/Media/ME1.json Messages/SM2.json Messages/SM3/Media/ME3.json]"
+	if fmt.Sprint(deleted) != want {
+		t.Errorf("expected deletes %s, got %v", want, deleted)
+	}
+}
@@ -1,7 +1,9 @@
 package twilio
 
 import (
+	"fmt"
 	"net/url"
+	"time"
 
 	"golang.org/x/net/context"
 )
@@ -76,6 +78,31 @@ func (o *OutgoingCallerIDService) Delete(ctx context.Context, sid string) error
 // OutgoingCallerIDPageIterator lets you retrieve consecutive pages of resources.
 type OutgoingCallerIDPageIterator struct {
 	p *PageIterator
+	// start and end are the bounds of a GetOutgoingCallerIDsInRange iterator,
+	// or the zero time otherwise.
+	start time.Time
+	end   time.Time
+}
+
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (o *OutgoingCallerIDPageIterator) Prefetch(depth int) {
+	o.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (o *OutgoingCallerIDPageIterator) Stop() {
+	o.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (o *OutgoingCallerIDPageIterator) Cursor() Cursor {
+	cur := o.p.Cursor()
+	cur.Start = o.start
+	cur.End = o.end
+	return cur
 }
 
 // GetPageIterator returns a OutgoingCallerIDPageIterator with the given page
@@ -88,9 +115,29 @@ func (o *OutgoingCallerIDService) GetPageIterator(data url.Values) *OutgoingCall
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the
+// Cursor of an earlier iterator.
+func (o *OutgoingCallerIDService) ResumePageIterator(cur Cursor) *OutgoingCallerIDPageIterator {
+	iter := ResumePageIterator(o.client, callerIDPathPart, cur)
+	return &OutgoingCallerIDPageIterator{
+		p:     iter,
+		start: cur.Start,
+		end:   cur.End,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
-// NoMoreResults is returned.
+// NoMoreResults is returned. If the iterator was returned by
+// GetOutgoingCallerIDsInRange, Next may fetch several pages to find one with
+// results in the range.
 func (o *OutgoingCallerIDPageIterator) Next(ctx context.Context) (*OutgoingCallerIDPage, error) {
+	if !o.end.IsZero() {
+		page, err := nextPageInRange(ctx, o.p, o.start, o.end, false, func() datedPage { return new(OutgoingCallerIDPage) })
+		if err != nil {
+			return nil, err
+		}
+		return page.(*OutgoingCallerIDPage), nil
+	}
 	op := new(OutgoingCallerIDPage)
 	err := o.p.Next(ctx, op)
 	if err != nil {
@@ -99,3 +146,90 @@ func (o *OutgoingCallerIDPageIterator) Next(ctx context.Context) (*OutgoingCalle
 	o.p.SetNextPageURI(op.NextPageURI)
 	return op, nil
 }
+
+// GetOutgoingCallerIDsInRange returns an iterator over the caller IDs created
+// in the range [start, end), optionally further filtered by data. start and end
+// may have any precision; caller IDs outside the range are removed from each
+// page. GetOutgoingCallerIDsInRange panics if start is after end. If you have
+// an end, but don't want to specify a start, use twilio.Epoch for start. If you
+// have a start, but don't want to specify an end, use twilio.HeatDeath for end.
+// The API can't filter caller IDs by date, and doesn't document the order
+// they're returned in, so this pages through every caller ID, filtering as it
+// goes.
+// Returned pages have at most PageSize results, but may have fewer.
+func (o *OutgoingCallerIDService) GetOutgoingCallerIDsInRange(start time.Time, end time.Time, data url.Values) *OutgoingCallerIDPageIterator {
+	if start.After(end) {
+		panic("start date is after end date")
+	}
+	d := url.Values{}
+	for k, v := range data {
+		d[k] = v
+	}
+	d.Del("Page") // just in case
+	return &OutgoingCallerIDPageIterator{
+		p:     NewPageIterator(o.client, d, callerIDPathPart),
+		start: start,
+		end:   end,
+	}
+}
+
+func (p *OutgoingCallerIDPage) dates() ([]time.Time, error) {
+	times := make([]time.Time, len(p.OutgoingCallerIDs))
+	for i, outgoingCallerID := range p.OutgoingCallerIDs {
+		if !outgoingCallerID.DateCreated.Valid {
+			return nil, fmt.Errorf("Couldn't verify the date of caller ID: %#v", outgoingCallerID)
+		}
+		times[i] = outgoingCallerID.DateCreated.Time
+	}
+	return times, nil
+}
+
+func (p *OutgoingCallerIDPage) removeIndexes(indexes []int) {
+	// reverse order so we don't delete the wrong index
+	for i := len(indexes) - 1; i >= 0; i-- {
+		p.OutgoingCallerIDs = append(p.OutgoingCallerIDs[:indexes[i]], p.OutgoingCallerIDs[indexes[i]+1:]...)
+	}
+}
+
+// OutgoingCallerIDIterator yields OutgoingCallerIDs one at a time. Call Next to
+// advance the iterator, and Value to get the current OutgoingCallerID.
+type OutgoingCallerIDIterator struct {
+	*ItemIterator
+}
+
+// NewOutgoingCallerIDIterator returns a OutgoingCallerIDIterator that yields
+// each OutgoingCallerID in the pages returned by p.
+func NewOutgoingCallerIDIterator(p *OutgoingCallerIDPageIterator) *OutgoingCallerIDIterator {
+	return &OutgoingCallerIDIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.OutgoingCallerIDs))
+		for i := range page.OutgoingCallerIDs {
+			items[i] = page.OutgoingCallerIDs[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current OutgoingCallerID, or nil if Next hasn't been called
+// or returned false.
+func (o *OutgoingCallerIDIterator) Value() *OutgoingCallerID {
+	v, _ := o.ItemIterator.Value().(*OutgoingCallerID)
+	return v
+}
+
+// GetIterator returns an iterator over the OutgoingCallerIDs matching the
+// filters in data, fetching new pages as needed.
+func (o *OutgoingCallerIDService) GetIterator(data url.Values) *OutgoingCallerIDIterator {
+	return NewOutgoingCallerIDIterator(o.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (o *OutgoingCallerIDService) ResumeIterator(cur Cursor) *OutgoingCallerIDIterator {
+	iter := NewOutgoingCallerIDIterator(o.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
@@ -1,6 +1,7 @@
 package twilio
 
 import (
+	"encoding/json"
 	"errors"
 	"net/url"
 	"strings"
@@ -34,12 +35,75 @@ type Meta struct {
 // paging through resources.
 var NoMoreResults = errors.New("twilio: No more results")
 
+// A Prefetcher can fetch pages in the background, while the caller works
+// through the current page. PageIterator and the page iterators returned by
+// each resource's GetPageIterator and Get...InRange methods implement
+// Prefetcher.
+type Prefetcher interface {
+	// Prefetch configures the iterator to fetch up to depth pages ahead of
+	// the caller. It must be called before the first call to Next.
+	Prefetch(depth int)
+	// Stop cancels any requests in flight and ends the iteration. Subsequent
+	// calls to Next return NoMoreResults.
+	Stop()
+}
+
+// A Checkpointer can report its position as a Cursor. PageIterator,
+// ItemIterator and the page iterators returned by each resource implement
+// Checkpointer.
+type Checkpointer interface {
+	Cursor() Cursor
+}
+
+// A Cursor records how far a page iterator has gotten, so a long-running
+// export can be resumed later, possibly by a different process, with the
+// ResumePageIterator or ResumeIterator method on the resource's service. A
+// Cursor can be serialized with encoding/json.
+type Cursor struct {
+	// NextPageURI is the page to fetch next. It's empty if no pages have been
+	// fetched yet, or if there are no more pages.
+	NextPageURI string `json:"next_page_uri,omitempty"`
+	// Filters are the query parameters used to fetch the first page.
+	Filters url.Values `json:"filters,omitempty"`
+	// Start and End are the bounds passed to a Get...InRange method. They are
+	// the zero time for other iterators.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Count is the number of pages fetched so far.
+	Count uint `json:"count"`
+	// Offset is the number of resources an ItemIterator already returned
+	// from the page at NextPageURI (or the first page, if NextPageURI is
+	// empty). These are skipped when the iterator is resumed.
+	Offset int `json:"offset,omitempty"`
+}
+
+// Done returns true if the iterator that produced c reached the last page.
+func (c Cursor) Done() bool {
+	return c.Count > 0 && c.NextPageURI == "" && c.Offset == 0
+}
+
+// inRange returns true if c was produced by a Get...InRange iterator.
+func (c Cursor) inRange() bool {
+	return !c.End.IsZero()
+}
+
 type PageIterator struct {
 	client      *Client
 	nextPageURI types.NullString
 	data        url.Values
 	count       uint
 	pathPart    string
+
+	depth   int
+	stopped bool
+	pages   chan prefetchedPage
+	cancel  context.CancelFunc
+}
+
+// prefetchedPage is a page (or an error) fetched in the background.
+type prefetchedPage struct {
+	body json.RawMessage
+	err  error
 }
 
 func (p *PageIterator) SetNextPageURI(npuri types.NullString) {
@@ -55,6 +119,12 @@ func (p *PageIterator) SetNextPageURI(npuri types.NullString) {
 
 // Next asks for the next page of resources and decodes the results into v.
 func (p *PageIterator) Next(ctx context.Context, v interface{}) error {
+	if p.stopped {
+		return NoMoreResults
+	}
+	if p.depth > 0 {
+		return p.nextPrefetched(ctx, v)
+	}
 	var err error
 	switch {
 	case p.nextPageURI.Valid:
@@ -71,6 +141,118 @@ func (p *PageIterator) Next(ctx context.Context, v interface{}) error {
 	return nil
 }
 
+// Cursor returns the position of p. Resuming from the Cursor fetches the page
+// after the last one returned by Next.
+func (p *PageIterator) Cursor() Cursor {
+	c := Cursor{Count: p.count}
+	if p.nextPageURI.Valid {
+		c.NextPageURI = p.nextPageURI.String
+	}
+	if len(p.data) > 0 {
+		c.Filters = make(url.Values, len(p.data))
+		for k, v := range p.data {
+			c.Filters[k] = append([]string(nil), v...)
+		}
+	}
+	return c
+}
+
+// Prefetch configures p to fetch up to depth pages ahead in the background,
+// so the next page is usually ready by the time the caller asks for it.
+// Pages are still returned in order. If depth is zero or less, pages are only
+// fetched when Next is called, which is the default.
+//
+// Prefetch must be called before the first call to Next. Background requests
+// use the Context passed to the first call to Next; cancel that Context or
+// call Stop to stop prefetching if you don't page through every result.
+// After an error, Next returns NoMoreResults.
+func (p *PageIterator) Prefetch(depth int) {
+	p.depth = depth
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (p *PageIterator) Stop() {
+	p.stopped = true
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+func (p *PageIterator) nextPrefetched(ctx context.Context, v interface{}) error {
+	if p.pages == nil {
+		p.startPrefetch(ctx)
+	}
+	select {
+	case page, ok := <-p.pages:
+		if !ok {
+			return NoMoreResults
+		}
+		if page.err != nil {
+			return page.err
+		}
+		p.count++
+		return json.Unmarshal(page.body, v)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// startPrefetch starts a goroutine that fetches pages and sends them on
+// p.pages, until there are no more pages, a request fails, or ctx is
+// cancelled.
+func (p *PageIterator) startPrefetch(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+	// The goroutine holds one page while it waits to send, so the buffer
+	// holds one fewer than depth.
+	pages := make(chan prefetchedPage, p.depth-1)
+	p.pages = pages
+	client, data, pathPart := p.client, p.data, p.pathPart
+	npuri, first := p.nextPageURI, p.count == 0
+	go func() {
+		defer close(pages)
+		for {
+			var body json.RawMessage
+			var err error
+			switch {
+			case npuri.Valid && npuri.String != "":
+				err = client.GetNextPage(ctx, npuri.String, &body)
+			case first:
+				err = client.ListResource(ctx, pathPart, data, &body)
+			default:
+				return
+			}
+			first = false
+			select {
+			case pages <- prefetchedPage{body: body, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+			npuri = nextPageURI(body)
+		}
+	}()
+}
+
+// nextPageURI returns the URI of the page after body, either from the
+// next_page_uri field used by the 2010-04-01 API or the meta.next_page_url
+// field used by Monitor and Pricing.
+func nextPageURI(body json.RawMessage) types.NullString {
+	page := new(struct {
+		NextPageURI types.NullString `json:"next_page_uri"`
+		Meta        Meta             `json:"meta"`
+	})
+	if err := json.Unmarshal(body, page); err != nil {
+		return types.NullString{}
+	}
+	if page.NextPageURI.Valid {
+		return page.NextPageURI
+	}
+	return page.Meta.NextPageURL
+}
+
 // NewPageIterator returns a PageIterator that can be used to iterate through
 // values. Call Next() to get the first page of values (and again to get
 // subsequent pages). If there are no more results, NoMoreResults is returned.
@@ -102,6 +284,98 @@ func NewNextPageIterator(client *Client, nextPageURI string) *PageIterator {
 	}
 }
 
+// ResumePageIterator returns a PageIterator for the resource at pathPart that
+// continues from the position recorded in c. If c is the zero Cursor, the
+// PageIterator starts at the first page. Most callers should use the
+// ResumePageIterator method on the resource's service instead.
+func ResumePageIterator(client *Client, pathPart string, c Cursor) *PageIterator {
+	p := NewPageIterator(client, c.Filters, pathPart)
+	p.count = c.Count
+	if c.NextPageURI != "" {
+		p.SetNextPageURI(types.NullString{Valid: true, String: c.NextPageURI})
+	}
+	return p
+}
+
+// A datedPage is a page of resources that each have a creation date, so
+// nextPageInRange can filter it.
+type datedPage interface {
+	// dates returns the DateCreated of each resource in the page, or an error
+	// if one of them isn't valid.
+	dates() ([]time.Time, error)
+	// removeIndexes removes the resources at the given indexes, which are in
+	// increasing order.
+	removeIndexes(indexes []int)
+	nextPageURI() types.NullString
+}
+
+func (p *Page) nextPageURI() types.NullString {
+	return p.NextPageURI
+}
+
+// nextPageInRange fetches pages with p, decoding each into a page returned by
+// newPage, until it finds a page with resources in [start, end), and returns
+// that page with the resources outside the range removed. It returns
+// NoMoreResults once there are no more resources in the range.
+//
+// If ordered is true, nextPageInRange assumes that Twilio returns resources in
+// chronological order, latest first, and stops paging at the first page that
+// ends before start. Otherwise it pages through every resource.
+func nextPageInRange(ctx context.Context, p *PageIterator, start time.Time, end time.Time, ordered bool, newPage func() datedPage) (datedPage, error) {
+	for {
+		// use a new page every time to avoid remnants hanging around
+		page := newPage()
+		if err := p.Next(ctx, page); err != nil {
+			return nil, err
+		}
+		times, err := page.dates()
+		if err != nil {
+			return nil, err
+		}
+		if len(times) == 0 {
+			p.Stop()
+			return nil, NoMoreResults
+		}
+		if containsResultsInRange(start, end, times) {
+			page.removeIndexes(indexesOutsideRange(start, end, times))
+			p.SetNextPageURI(page.nextPageURI())
+			return page, nil
+		}
+		if ordered && !shouldContinuePaging(start, times) {
+			// no results in range, and there won't be any on later pages.
+			// Stop so a prefetching iterator doesn't keep fetching pages
+			// outside the range.
+			p.Stop()
+			return nil, NoMoreResults
+		}
+		p.SetNextPageURI(page.nextPageURI())
+	}
+}
+
+// rangeFilters returns a copy of data with filters on param (for example,
+// "DateCreated") set to cover [start, end). The API only filters by day, so
+// pages may still contain resources outside the range.
+func rangeFilters(data url.Values, param string, start time.Time, end time.Time) url.Values {
+	if start.After(end) {
+		panic("start date is after end date")
+	}
+	d := url.Values{}
+	for k, v := range data {
+		d[k] = v
+	}
+	d.Del(param)
+	d.Del("Page") // just in case
+	if start != Epoch {
+		d.Set(param+">", start.UTC().Format(APISearchLayout))
+	}
+	if end != HeatDeath {
+		// "DateCreated<=YYYY-MM-DD" excludes everything after midnight on DD,
+		// so ask for DD+1.
+		d.Set(param+"<", end.UTC().Add(24*time.Hour).Format(APISearchLayout))
+	}
+	return d
+}
+
 // containsResultsInRange returns true if any results are in the range
 // [start, end).
 func containsResultsInRange(start time.Time, end time.Time, results []time.Time) bool {
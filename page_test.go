@@ -0,0 +1,443 @@
+package twilio
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// pagingServer serves numbered pages of Messages and Alerts, each with a
+// single resource. If total is zero, there's always another page.
+type pagingServer struct {
+	*httptest.Server
+	total int
+
+	mu       sync.Mutex
+	requests int
+}
+
+func newPagingServer(total int) *pagingServer {
+	ps := &pagingServer{total: total}
+	ps.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ps.mu.Lock()
+		ps.requests++
+		ps.mu.Unlock()
+		page, _ := strconv.Atoi(r.URL.Query().Get("Page"))
+		last := ps.total > 0 && page == ps.total-1
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/Alerts" {
+			next := "null"
+			if !last {
+				next = fmt.Sprintf(`"%s/v1/Alerts?Page=%d"`, ps.URL, page+1)
+			}
+			fmt.Fprintf(w, `{"alerts": [{"sid": "NO%d"}], "meta": {"next_page_url": %s}}`, page, next)
+			return
+		}
+		next := "null"
+		if !last {
+			next = fmt.Sprintf(`"%s?Page=%d"`, r.URL.Path, page+1)
+		}
+		fmt.Fprintf(w, `{"messages": [{"sid": "SM%d"}], "next_page_uri": %s}`, page, next)
+	}))
+	return ps
+}
+
+func (ps *pagingServer) count() int {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.requests
+}
+
+func (ps *pagingServer) client() *Client {
+	client := NewClient("AC123", "456", nil)
+	client.Base = ps.URL
+	client.Monitor.Base = ps.URL
+	return client
+}
+
+func TestPrefetchPreservesOrder(t *testing.T) {
+	t.Parallel()
+	ps := newPagingServer(5)
+	defer ps.Close()
+	iter := ps.client().Messages.GetIterator(nil)
+	iter.Prefetch(2)
+	i := 0
+	for iter.Next(context.Background()) {
+		if want := fmt.Sprintf("SM%d", i); iter.Value().Sid != want {
+			t.Errorf("message %d: expected sid %s, got %s", i, want, iter.Value().Sid)
+		}
+		i++
+	}
+	if err := iter.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != 5 {
+		t.Errorf("expected 5 messages, got %d", i)
+	}
+	if c := ps.count(); c != 5 {
+		t.Errorf("expected 5 requests, got %d", c)
+	}
+}
+
+func TestPrefetchMonitor(t *testing.T) {
+	t.Parallel()
+	ps := newPagingServer(3)
+	defer ps.Close()
+	iter := ps.client().Monitor.Alerts.GetPageIterator(nil)
+	iter.(Prefetcher).Prefetch(1)
+	for i := 0; i < 3; i++ {
+		page, err := iter.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("NO%d", i); page.Alerts[0].Sid != want {
+			t.Errorf("page %d: expected sid %s, got %s", i, want, page.Alerts[0].Sid)
+		}
+	}
+	if _, err := iter.Next(context.Background()); err != NoMoreResults {
+		t.Errorf("expected NoMoreResults, got %v", err)
+	}
+}
+
+func TestPrefetchDepth(t *testing.T) {
+	t.Parallel()
+	ps := newPagingServer(0)
+	defer ps.Close()
+	iter := NewPageIterator(ps.client(), nil, messagesPathPart)
+	iter.Prefetch(2)
+	defer iter.Stop()
+	if err := iter.Next(context.Background(), new(MessagePage)); err != nil {
+		t.Fatal(err)
+	}
+	// The first page, plus two more in the background.
+	deadline := time.Now().Add(time.Second)
+	for ps.count() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if c := ps.count(); c != 3 {
+		t.Errorf("expected 3 requests, got %d", c)
+	}
+}
+
+// waitClosed returns true if the prefetch goroutine exits within a second.
+func waitClosed(p *PageIterator) bool {
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-p.pages:
+			if !ok {
+				return true
+			}
+		case <-timeout:
+			return false
+		}
+	}
+}
+
+func TestPrefetchStop(t *testing.T) {
+	t.Parallel()
+	ps := newPagingServer(0)
+	defer ps.Close()
+	iter := NewPageIterator(ps.client(), nil, messagesPathPart)
+	iter.Prefetch(3)
+	if err := iter.Next(context.Background(), new(MessagePage)); err != nil {
+		t.Fatal(err)
+	}
+	iter.Stop()
+	if err := iter.Next(context.Background(), new(MessagePage)); err != NoMoreResults {
+		t.Errorf("expected NoMoreResults after Stop, got %v", err)
+	}
+	if !waitClosed(iter) {
+		t.Error("prefetch goroutine didn't exit after Stop")
+	}
+}
+
+func TestPrefetchContextCancel(t *testing.T) {
+	t.Parallel()
+	ps := newPagingServer(0)
+	defer ps.Close()
+	iter := NewPageIterator(ps.client(), nil, messagesPathPart)
+	iter.Prefetch(2)
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := iter.Next(ctx, new(MessagePage)); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if !waitClosed(iter) {
+		t.Error("prefetch goroutine didn't exit after the context was cancelled")
+	}
+}
+
+func TestItemIteratorStopCancelsPrefetch(t *testing.T) {
+	t.Parallel()
+	ps := newPagingServer(0)
+	defer ps.Close()
+	pages := NewPageIterator(ps.client(), nil, messagesPathPart)
+	iter := NewMessageIterator(&messagePageIterator{p: pages})
+	iter.Prefetch(2)
+	iter.SetLimit(3)
+	for iter.Next(context.Background()) {
+	}
+	if err := iter.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !waitClosed(pages) {
+		t.Error("prefetch goroutine didn't exit after reaching the limit")
+	}
+}
+
+// roundTrip encodes c as JSON and decodes it again, as a checkpoint would.
+func roundTrip(t *testing.T, c Cursor) Cursor {
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cur Cursor
+	if err := json.Unmarshal(data, &cur); err != nil {
+		t.Fatal(err)
+	}
+	return cur
+}
+
+func TestResumePageIterator(t *testing.T) {
+	t.Parallel()
+	ps := newPagingServer(4)
+	defer ps.Close()
+	client := ps.client()
+	iter := client.Messages.GetPageIterator(url.Values{"To": []string{to}})
+	for i := 0; i < 2; i++ {
+		if _, err := iter.Next(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cur := roundTrip(t, iter.(Checkpointer).Cursor())
+	if cur.Count != 2 || cur.NextPageURI == "" || cur.Filters.Get("To") != to {
+		t.Errorf("unexpected cursor: %#v", cur)
+	}
+	iter = client.Messages.ResumePageIterator(cur)
+	for i := 2; i < 4; i++ {
+		page, err := iter.Next(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("SM%d", i); page.Messages[0].Sid != want {
+			t.Errorf("page %d: expected sid %s, got %s", i, want, page.Messages[0].Sid)
+		}
+	}
+	if _, err := iter.Next(context.Background()); err != NoMoreResults {
+		t.Errorf("expected NoMoreResults, got %v", err)
+	}
+	done := roundTrip(t, iter.(Checkpointer).Cursor())
+	if !done.Done() {
+		t.Errorf("expected cursor to be done: %#v", done)
+	}
+	if _, err := client.Messages.ResumePageIterator(done).Next(context.Background()); err != NoMoreResults {
+		t.Errorf("expected NoMoreResults from a finished cursor, got %v", err)
+	}
+}
+
+func TestResumeIteratorSkipsReturnedItems(t *testing.T) {
+	t.Parallel()
+	// accountList always has a next page, with 2 accounts per page.
+	client, s := getServer(accountList)
+	defer s.Close()
+	iter := client.Accounts.GetIterator(nil)
+	for i := 0; i < 3; i++ {
+		if !iter.Next(context.Background()) {
+			t.Fatal(iter.Err())
+		}
+	}
+	cur := roundTrip(t, iter.Cursor())
+	if cur.Offset != 1 || cur.Count != 1 || cur.NextPageURI == "" {
+		t.Errorf("unexpected cursor: %#v", cur)
+	}
+	resumed := client.Accounts.ResumeIterator(cur)
+	if !resumed.Next(context.Background()) {
+		t.Fatal(resumed.Err())
+	}
+	if sid := resumed.Value().Sid; sid != "ACdd54a711c3d4031ac500c5236ab121d7" {
+		t.Errorf("expected to resume at the second account, got %s", sid)
+	}
+	if l := len(s.URLs); l != 3 {
+		t.Errorf("expected 3 requests, got %d", l)
+	}
+	// The rest of the page has been returned, so the next Cursor points at
+	// the next page.
+	if cur := resumed.Cursor(); cur.Offset != 0 || cur.Count != 2 {
+		t.Errorf("expected cursor at a page boundary, got %#v", cur)
+	}
+}
+
+func TestResumeInRange(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(conferencePage)
+	defer s.Close()
+	start := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	iter := client.Conferences.GetConferencesInRange(start, HeatDeath, nil)
+	cur := roundTrip(t, iter.(Checkpointer).Cursor())
+	if !cur.Start.Equal(start) || !cur.End.Equal(HeatDeath) {
+		t.Errorf("expected cursor to have range bounds, got %#v", cur)
+	}
+	resumed := client.Conferences.ResumePageIterator(cur)
+	if _, ok := resumed.(*conferenceDateIterator); !ok {
+		t.Errorf("expected a date iterator, got %T", resumed)
+	}
+	if _, ok := client.Conferences.ResumePageIterator(Cursor{}).(*conferencePageIterator); !ok {
+		t.Errorf("expected a page iterator from the zero Cursor")
+	}
+}
+
+// rangeServer serves an endless list of Calls and Recordings, one per page,
+// each a day older than the last, starting on 2016-11-10.
+func rangeServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("Page"))
+		date := time.Date(2016, 11, 10-page, 12, 0, 0, 0, time.UTC).Format(TimeLayout)
+		key := "calls"
+		if strings.HasSuffix(r.URL.Path, "/Recordings.json") {
+			key = "recordings"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"%s": [{"sid": "XX%d", "date_created": %q}], "next_page_uri": "%s?Page=%d"}`, key, page, date, r.URL.Path, page+1)
+	}))
+}
+
+// Draining a prefetching range iterator should stop the prefetch goroutine,
+// which would otherwise block forever holding pages outside the range. Not
+// parallel, since it counts goroutines.
+func TestPrefetchRangeStops(t *testing.T) {
+	before := runtime.NumGoroutine()
+	s := rangeServer()
+	tr := &http.Transport{}
+	client := NewClient("AC123", "456", &http.Client{Transport: tr})
+	client.Base = s.URL
+	start := time.Date(2016, 11, 8, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2016, 11, 10, 0, 0, 0, 0, time.UTC)
+	calls := client.Calls.GetCallsInRange(start, end, nil)
+	recordings := client.Recordings.GetRecordingsInRange(start, end, nil)
+	calls.(Prefetcher).Prefetch(3)
+	recordings.Prefetch(3)
+	ctx := context.Background()
+	for _, next := range []func() (int, error){
+		func() (int, error) {
+			page, err := calls.Next(ctx)
+			if err != nil {
+				return 0, err
+			}
+			return len(page.Calls), nil
+		},
+		func() (int, error) {
+			page, err := recordings.Next(ctx)
+			if err != nil {
+				return 0, err
+			}
+			return len(page.Recordings), nil
+		},
+	} {
+		total := 0
+		for {
+			n, err := next()
+			if err == NoMoreResults {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			total += n
+		}
+		if total != 2 {
+			t.Errorf("expected 2 results in range, got %d", total)
+		}
+	}
+	s.Close()
+	tr.CloseIdleConnections()
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("expected %d goroutines after draining, got %d", before, n)
+	}
+}
+
+// Accounts and caller IDs are filtered client-side, with no documented order,
+// so a page with nothing in the range shouldn't end the iteration.
+func TestClientSideRangeOutOfOrder(t *testing.T) {
+	t.Parallel()
+	dates := []time.Time{
+		time.Date(2016, 11, 9, 12, 0, 0, 0, time.UTC),
+		time.Date(2015, 1, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2016, 11, 8, 12, 0, 0, 0, time.UTC),
+	}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("Page"))
+		key := "accounts"
+		if strings.HasSuffix(r.URL.Path, "/OutgoingCallerIds.json") {
+			key = "outgoing_caller_ids"
+		}
+		next := "null"
+		if page < len(dates)-1 {
+			next = fmt.Sprintf(`"%s?Page=%d"`, r.URL.Path, page+1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"%s": [{"sid": "XX%d", "date_created": %q}], "next_page_uri": %s}`, key, page, dates[page].Format(TimeLayout), next)
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	start := time.Date(2016, 11, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2016, 11, 10, 0, 0, 0, 0, time.UTC)
+	accounts := client.Accounts.GetAccountsInRange(start, end, nil)
+	callerIDs := client.OutgoingCallerIDs.GetOutgoingCallerIDsInRange(start, end, nil)
+	ctx := context.Background()
+	for _, next := range []func() ([]string, error){
+		func() ([]string, error) {
+			page, err := accounts.Next(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var sids []string
+			for _, a := range page.Accounts {
+				sids = append(sids, a.Sid)
+			}
+			return sids, nil
+		},
+		func() ([]string, error) {
+			page, err := callerIDs.Next(ctx)
+			if err != nil {
+				return nil, err
+			}
+			var sids []string
+			for _, o := range page.OutgoingCallerIDs {
+				sids = append(sids, o.Sid)
+			}
+			return sids, nil
+		},
+	} {
+		var all []string
+		for {
+			sids, err := next()
+			if err == NoMoreResults {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			all = append(all, sids...)
+		}
+		if len(all) != 2 || all[0] != "XX0" || all[1] != "XX2" {
+			t.Errorf("expected [XX0 XX2], got %v", all)
+		}
+	}
+}
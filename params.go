@@ -0,0 +1,455 @@
+package twilio
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A ValidationError is returned when parameters fail client-side validation,
+// before a request is made to Twilio.
+type ValidationError struct {
+	// The Twilio parameter that failed validation, for example "To".
+	Field string
+	// The error code Twilio would likely have returned for the parameter, or
+	// zero if there isn't one.
+	Code    Code
+	Message string
+}
+
+func (v *ValidationError) Error() string {
+	return fmt.Sprintf("twilio: invalid %s: %s", v.Field, v.Message)
+}
+
+// The limits that Twilio enforces on outgoing messages.
+const (
+	// MaxMediaURLs is the most media URLs that can be attached to a message.
+	MaxMediaURLs = 10
+	// MaxBodyLength is the longest allowed message body, in characters.
+	MaxBodyLength = 1600
+	// MaxValidityPeriod is the longest a message can wait in the queue.
+	MaxValidityPeriod = 4 * time.Hour
+)
+
+// MessageParams are the parameters for sending a message, as an alternative
+// to building url.Values by hand. Pass them to MessageService.CreateWithParams,
+// which checks them before making a request. For more information, see
+// https://www.twilio.com/docs/api/rest/sending-messages.
+type MessageParams struct {
+	// To is the recipient's phone number. Numbers without a leading plus sign
+	// are assumed to be US numbers, and all numbers are sent in E.164 format.
+	To string
+	// From is a Twilio phone number, short code or alphanumeric sender ID.
+	// Either From or MessagingServiceSid is required.
+	From                string
+	MessagingServiceSid string
+	// Either Body or MediaURLs is required.
+	Body      string
+	MediaURLs []*url.URL
+	// StatusCallback, if set, receives a POST each time the message status
+	// changes.
+	StatusCallback *url.URL
+	// ApplicationSid is an Application whose MessageStatusCallback receives
+	// status updates. It's ignored if StatusCallback is set.
+	ApplicationSid string
+	// MaxPrice is the most you're willing to pay for the message, in US
+	// dollars, for example "0.05".
+	MaxPrice string
+	// ProvideFeedback requests delivery confirmation feedback for the
+	// message.
+	ProvideFeedback bool
+	// ValidityPeriod is how long the message may wait in the queue before it
+	// fails. It must be a whole number of seconds, up to MaxValidityPeriod.
+	ValidityPeriod time.Duration
+	// Extra holds parameters not covered by the fields above. They are added
+	// to the request as is, after the fields above.
+	Extra url.Values
+}
+
+// Validate checks p against the rules Twilio enforces, and returns a
+// *ValidationError describing the first problem found, or nil.
+func (p *MessageParams) Validate() error {
+	_, err := p.Values()
+	return err
+}
+
+// Values validates p and encodes it as the url.Values expected by
+// MessageService.Create.
+func (p *MessageParams) Values() (url.Values, error) {
+	v := url.Values{}
+	if p.To == "" {
+		return nil, &ValidationError{Field: "To", Code: CodeMessageToRequired, Message: "a To number is required"}
+	}
+	to, err := NewPhoneNumber(p.To)
+	if err != nil {
+		return nil, &ValidationError{Field: "To", Code: CodeInvalidToNumber, Message: err.Error()}
+	}
+	v.Set("To", string(to))
+	switch {
+	case p.From != "":
+		from, err := messageSender(p.From)
+		if err != nil {
+			return nil, &ValidationError{Field: "From", Code: CodeInvalidFromNumber, Message: err.Error()}
+		}
+		v.Set("From", from)
+	case p.MessagingServiceSid == "":
+		return nil, &ValidationError{Field: "From", Code: CodeMessageFromRequired, Message: "a From number or MessagingServiceSid is required"}
+	}
+	if p.MessagingServiceSid != "" {
+		if !strings.HasPrefix(p.MessagingServiceSid, "MG") {
+			return nil, &ValidationError{Field: "MessagingServiceSid", Code: CodeMessagingServiceNotFound, Message: "sid should begin with MG: " + p.MessagingServiceSid}
+		}
+		v.Set("MessagingServiceSid", p.MessagingServiceSid)
+	}
+	if p.Body == "" && len(p.MediaURLs) == 0 {
+		return nil, &ValidationError{Field: "Body", Code: CodeBodyOrMediaRequired, Message: "a Body or at least one MediaUrl is required"}
+	}
+	if n := utf8.RuneCountInString(p.Body); n > MaxBodyLength {
+		return nil, &ValidationError{Field: "Body", Code: CodeMessageBodyTooLong, Message: fmt.Sprintf("body is %d characters, the limit is %d", n, MaxBodyLength)}
+	}
+	if p.Body != "" {
+		v.Set("Body", p.Body)
+	}
+	if len(p.MediaURLs) > MaxMediaURLs {
+		return nil, &ValidationError{Field: "MediaUrl", Code: CodeTooManyMediaFiles, Message: fmt.Sprintf("%d media URLs provided, the limit is %d", len(p.MediaURLs), MaxMediaURLs)}
+	}
+	for _, u := range p.MediaURLs {
+		if err := checkURL(u); err != nil {
+			return nil, &ValidationError{Field: "MediaUrl", Code: CodeInvalidMediaURL, Message: err.Error()}
+		}
+		v.Add("MediaUrl", u.String())
+	}
+	if p.StatusCallback != nil {
+		if err := checkURL(p.StatusCallback); err != nil {
+			return nil, &ValidationError{Field: "StatusCallback", Code: CodeInvalidStatusCallback, Message: err.Error()}
+		}
+		v.Set("StatusCallback", p.StatusCallback.String())
+	}
+	if p.ApplicationSid != "" {
+		if !strings.HasPrefix(p.ApplicationSid, "AP") {
+			return nil, &ValidationError{Field: "ApplicationSid", Code: CodeInvalidApplicationSid, Message: "sid should begin with AP: " + p.ApplicationSid}
+		}
+		v.Set("ApplicationSid", p.ApplicationSid)
+	}
+	if p.MaxPrice != "" {
+		if f, err := strconv.ParseFloat(p.MaxPrice, 64); err != nil || f <= 0 {
+			return nil, &ValidationError{Field: "MaxPrice", Message: "should be a positive number of dollars: " + p.MaxPrice}
+		}
+		v.Set("MaxPrice", p.MaxPrice)
+	}
+	if p.ProvideFeedback {
+		v.Set("ProvideFeedback", "true")
+	}
+	if p.ValidityPeriod != 0 {
+		if err := checkSeconds(p.ValidityPeriod, time.Second, MaxValidityPeriod); err != nil {
+			return nil, &ValidationError{Field: "ValidityPeriod", Message: err.Error()}
+		}
+		v.Set("ValidityPeriod", strconv.FormatInt(int64(p.ValidityPeriod/time.Second), 10))
+	}
+	for k, vals := range p.Extra {
+		for _, val := range vals {
+			v.Add(k, val)
+		}
+	}
+	return v, nil
+}
+
+// messageSender returns from in the format Twilio expects, or an error if
+// it's not a phone number, short code or alphanumeric sender ID.
+func messageSender(from string) (string, error) {
+	if isShortCode(from) {
+		return from, nil
+	}
+	if isAlphanumericSenderID(from) {
+		return from, nil
+	}
+	pn, err := NewPhoneNumber(from)
+	if err != nil {
+		return "", err
+	}
+	return string(pn), nil
+}
+
+// isShortCode returns true if s is a 5 or 6 digit short code.
+func isShortCode(s string) bool {
+	if len(s) < 5 || len(s) > 6 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphanumericSenderID returns true if s is a valid alphanumeric sender ID:
+// up to 11 letters, digits and spaces, with at least one letter.
+func isAlphanumericSenderID(s string) bool {
+	if len(s) == 0 || len(s) > 11 {
+		return false
+	}
+	letter := false
+	for _, r := range s {
+		switch {
+		case r < utf8.RuneSelf && unicode.IsLetter(r):
+			letter = true
+		case r >= '0' && r <= '9', r == ' ':
+		default:
+			return false
+		}
+	}
+	return letter
+}
+
+// checkURL returns an error if u isn't an absolute HTTP or HTTPS URL.
+func checkURL(u *url.URL) error {
+	if u == nil {
+		return errors.New("URL is nil")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("should be an absolute http or https URL: %s", u.String())
+	}
+	return nil
+}
+
+// A CallEvent is a change in call status that can be reported to a
+// StatusCallback.
+type CallEvent string
+
+const (
+	CallEventInitiated CallEvent = "initiated"
+	CallEventRinging   CallEvent = "ringing"
+	CallEventAnswered  CallEvent = "answered"
+	CallEventCompleted CallEvent = "completed"
+)
+
+// Values for CallParams.MachineDetection.
+const (
+	// Return as soon as a human or machine is detected.
+	MachineDetectionEnable = "Enable"
+	// If a machine is detected, wait for the end of its greeting.
+	MachineDetectionDetectMessageEnd = "DetectMessageEnd"
+)
+
+// The limits that Twilio enforces on outgoing calls.
+const (
+	// MaxCallTimeout is the longest Twilio will let a call ring.
+	MaxCallTimeout = 600 * time.Second
+	// MinMachineDetectionTimeout and MaxMachineDetectionTimeout bound how long
+	// Twilio will try to detect a machine.
+	MinMachineDetectionTimeout = 3 * time.Second
+	MaxMachineDetectionTimeout = 59 * time.Second
+)
+
+// CallParams are the parameters for making a call, as an alternative to
+// building url.Values by hand. Pass them to CallService.CreateWithParams,
+// which checks them before making a request. For more information, see
+// https://www.twilio.com/docs/api/rest/making-calls.
+type CallParams struct {
+	// To is the phone number, SIP address ("sip:...") or client
+	// ("client:...") to call. Phone numbers without a leading plus sign are
+	// assumed to be US numbers, and all numbers are sent in E.164 format.
+	To string
+	// From is a Twilio phone number or verified caller ID, or a client.
+	From string
+	// Twilio fetches TwiML from URL when the call connects, using Method
+	// ("GET" or "POST", the default). Exactly one of URL and ApplicationSid
+	// is required.
+	URL            *url.URL
+	Method         string
+	ApplicationSid string
+	// FallbackURL is requested, using FallbackMethod, if the request to URL
+	// fails.
+	FallbackURL    *url.URL
+	FallbackMethod string
+	// StatusCallback, if set, is requested using StatusCallbackMethod when
+	// each of StatusCallbackEvents happens. If StatusCallbackEvents is empty,
+	// Twilio only reports when the call is completed.
+	StatusCallback       *url.URL
+	StatusCallbackMethod string
+	StatusCallbackEvents []CallEvent
+	// SendDigits are played once the call connects. Valid digits are 0-9, #,
+	// * and w, which waits half a second.
+	SendDigits string
+	// Timeout is how long to let the call ring before giving up. It must be a
+	// whole number of seconds, up to MaxCallTimeout.
+	Timeout time.Duration
+	// Record the whole call.
+	Record bool
+	// MachineDetection is MachineDetectionEnable or
+	// MachineDetectionDetectMessageEnd to detect whether a human or a machine
+	// answers. MachineDetectionTimeout limits how long detection takes.
+	MachineDetection        string
+	MachineDetectionTimeout time.Duration
+	// SipAuthUsername and SipAuthPassword authenticate calls to a SIP
+	// address.
+	SipAuthUsername string
+	SipAuthPassword string
+	// Extra holds parameters not covered by the fields above. They are added
+	// to the request as is, after the fields above.
+	Extra url.Values
+}
+
+// Validate checks p against the rules Twilio enforces, and returns a
+// *ValidationError describing the first problem found, or nil.
+func (p *CallParams) Validate() error {
+	_, err := p.Values()
+	return err
+}
+
+// Values validates p and encodes it as the url.Values expected by
+// CallService.Create.
+func (p *CallParams) Values() (url.Values, error) {
+	v := url.Values{}
+	if p.To == "" {
+		return nil, &ValidationError{Field: "To", Code: CodeNoToNumber, Message: "a To number is required"}
+	}
+	to, err := callEndpoint(p.To, true)
+	if err != nil {
+		return nil, &ValidationError{Field: "To", Code: CodeInvalidToNumber, Message: err.Error()}
+	}
+	v.Set("To", to)
+	if p.From == "" {
+		return nil, &ValidationError{Field: "From", Code: CodeFromNumberRequired, Message: "a From number is required"}
+	}
+	from, err := callEndpoint(p.From, false)
+	if err != nil {
+		return nil, &ValidationError{Field: "From", Code: CodeInvalidFromNumber, Message: err.Error()}
+	}
+	v.Set("From", from)
+	switch {
+	case p.URL == nil && p.ApplicationSid == "":
+		return nil, &ValidationError{Field: "Url", Code: CodeInvalidURL, Message: "a Url or ApplicationSid is required"}
+	case p.URL != nil && p.ApplicationSid != "":
+		return nil, &ValidationError{Field: "ApplicationSid", Message: "Url and ApplicationSid can't both be set"}
+	case p.URL != nil:
+		if err := checkURL(p.URL); err != nil {
+			return nil, &ValidationError{Field: "Url", Code: CodeInvalidURL, Message: err.Error()}
+		}
+		v.Set("Url", p.URL.String())
+	default:
+		if !strings.HasPrefix(p.ApplicationSid, "AP") {
+			return nil, &ValidationError{Field: "ApplicationSid", Code: CodeInvalidApplicationSid, Message: "sid should begin with AP: " + p.ApplicationSid}
+		}
+		v.Set("ApplicationSid", p.ApplicationSid)
+	}
+	if p.FallbackURL != nil {
+		if err := checkURL(p.FallbackURL); err != nil {
+			return nil, &ValidationError{Field: "FallbackUrl", Code: CodeInvalidURL, Message: err.Error()}
+		}
+		v.Set("FallbackUrl", p.FallbackURL.String())
+	}
+	if p.StatusCallback != nil {
+		if err := checkURL(p.StatusCallback); err != nil {
+			return nil, &ValidationError{Field: "StatusCallback", Code: CodeInvalidURL, Message: err.Error()}
+		}
+		v.Set("StatusCallback", p.StatusCallback.String())
+	}
+	if len(p.StatusCallbackEvents) > 0 && p.StatusCallback == nil {
+		return nil, &ValidationError{Field: "StatusCallbackEvent", Message: "StatusCallbackEvents require a StatusCallback"}
+	}
+	for _, event := range p.StatusCallbackEvents {
+		switch event {
+		case CallEventInitiated, CallEventRinging, CallEventAnswered, CallEventCompleted:
+			v.Add("StatusCallbackEvent", string(event))
+		default:
+			return nil, &ValidationError{Field: "StatusCallbackEvent", Message: "unknown event: " + string(event)}
+		}
+	}
+	for _, m := range []struct {
+		field  string
+		method string
+	}{
+		{"Method", p.Method},
+		{"FallbackMethod", p.FallbackMethod},
+		{"StatusCallbackMethod", p.StatusCallbackMethod},
+	} {
+		if m.method == "" {
+			continue
+		}
+		if m.method != "GET" && m.method != "POST" {
+			return nil, &ValidationError{Field: m.field, Code: CodeInvalidMethod, Message: "should be GET or POST: " + m.method}
+		}
+		v.Set(m.field, m.method)
+	}
+	if p.SendDigits != "" {
+		if i := strings.IndexFunc(p.SendDigits, func(r rune) bool {
+			return !strings.ContainsRune("0123456789#*w", r)
+		}); i >= 0 {
+			return nil, &ValidationError{Field: "SendDigits", Message: fmt.Sprintf("invalid digit %q", p.SendDigits[i:i+1])}
+		}
+		v.Set("SendDigits", p.SendDigits)
+	}
+	if p.Timeout != 0 {
+		if err := checkSeconds(p.Timeout, time.Second, MaxCallTimeout); err != nil {
+			return nil, &ValidationError{Field: "Timeout", Message: err.Error()}
+		}
+		v.Set("Timeout", strconv.FormatInt(int64(p.Timeout/time.Second), 10))
+	}
+	if p.Record {
+		v.Set("Record", "true")
+	}
+	switch p.MachineDetection {
+	case "":
+		if p.MachineDetectionTimeout != 0 {
+			return nil, &ValidationError{Field: "MachineDetectionTimeout", Message: "MachineDetectionTimeout requires MachineDetection"}
+		}
+	case MachineDetectionEnable, MachineDetectionDetectMessageEnd:
+		v.Set("MachineDetection", p.MachineDetection)
+	default:
+		return nil, &ValidationError{Field: "MachineDetection", Message: fmt.Sprintf("should be %s or %s: %s", MachineDetectionEnable, MachineDetectionDetectMessageEnd, p.MachineDetection)}
+	}
+	if p.MachineDetectionTimeout != 0 {
+		if err := checkSeconds(p.MachineDetectionTimeout, MinMachineDetectionTimeout, MaxMachineDetectionTimeout); err != nil {
+			return nil, &ValidationError{Field: "MachineDetectionTimeout", Message: err.Error()}
+		}
+		v.Set("MachineDetectionTimeout", strconv.FormatInt(int64(p.MachineDetectionTimeout/time.Second), 10))
+	}
+	if p.SipAuthUsername != "" || p.SipAuthPassword != "" {
+		if !strings.HasPrefix(to, "sip:") {
+			return nil, &ValidationError{Field: "SipAuthUsername", Message: "SIP credentials require a sip: To address"}
+		}
+		if p.SipAuthUsername == "" || p.SipAuthPassword == "" {
+			return nil, &ValidationError{Field: "SipAuthUsername", Message: "SipAuthUsername and SipAuthPassword must both be set"}
+		}
+		v.Set("SipAuthUsername", p.SipAuthUsername)
+		v.Set("SipAuthPassword", p.SipAuthPassword)
+	}
+	for k, vals := range p.Extra {
+		for _, val := range vals {
+			v.Add(k, val)
+		}
+	}
+	return v, nil
+}
+
+// callEndpoint returns s in the format Twilio expects, or an error if it's
+// not a phone number or client. If sip is true, SIP addresses are allowed.
+func callEndpoint(s string, sip bool) (string, error) {
+	if strings.HasPrefix(s, "client:") && len(s) > len("client:") {
+		return s, nil
+	}
+	if sip && strings.HasPrefix(s, "sip:") && len(s) > len("sip:") {
+		return s, nil
+	}
+	pn, err := NewPhoneNumber(s)
+	if err != nil {
+		return "", err
+	}
+	return string(pn), nil
+}
+
+// checkSeconds returns an error if d isn't a whole number of seconds in the
+// range [min, max].
+func checkSeconds(d time.Duration, min time.Duration, max time.Duration) error {
+	if d < min || d > max || d%time.Second != 0 {
+		return fmt.Errorf("should be a whole number of seconds between %v and %v: %v", min, max, d)
+	}
+	return nil
+}
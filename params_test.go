@@ -0,0 +1,215 @@
+package twilio
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+var messageParamsTests = []struct {
+	name  string
+	p     MessageParams
+	field string
+	code  Code
+}{
+	{"missing to", MessageParams{From: from, Body: "hi"}, "To", CodeMessageToRequired},
+	{"bad to", MessageParams{To: "foo", From: from, Body: "hi"}, "To", CodeInvalidToNumber},
+	{"missing from", MessageParams{To: to, Body: "hi"}, "From", CodeMessageFromRequired},
+	{"bad from", MessageParams{To: to, From: "+1 (foo)", Body: "hi"}, "From", CodeInvalidFromNumber},
+	{"bad service", MessageParams{To: to, MessagingServiceSid: "PN123", Body: "hi"}, "MessagingServiceSid", CodeMessagingServiceNotFound},
+	{"no body", MessageParams{To: to, From: from}, "Body", CodeBodyOrMediaRequired},
+	{"long body", MessageParams{To: to, From: from, Body: strings.Repeat("é", MaxBodyLength+1)}, "Body", CodeMessageBodyTooLong},
+	{"too much media", MessageParams{To: to, From: from, MediaURLs: make([]*url.URL, MaxMediaURLs+1)}, "MediaUrl", CodeTooManyMediaFiles},
+	{"relative media", MessageParams{To: to, From: from, MediaURLs: []*url.URL{{Path: "/cat.jpg"}}}, "MediaUrl", CodeInvalidMediaURL},
+	{"bad callback", MessageParams{To: to, From: from, Body: "hi", StatusCallback: &url.URL{Scheme: "ftp", Host: "example.com"}}, "StatusCallback", CodeInvalidStatusCallback},
+	{"bad application", MessageParams{To: to, From: from, Body: "hi", ApplicationSid: "CA123"}, "ApplicationSid", CodeInvalidApplicationSid},
+	{"bad price", MessageParams{To: to, From: from, Body: "hi", MaxPrice: "-1"}, "MaxPrice", 0},
+	{"long validity", MessageParams{To: to, From: from, Body: "hi", ValidityPeriod: 5 * time.Hour}, "ValidityPeriod", 0},
+	{"fractional validity", MessageParams{To: to, From: from, Body: "hi", ValidityPeriod: 1500 * time.Millisecond}, "ValidityPeriod", 0},
+}
+
+func TestMessageParamsValidation(t *testing.T) {
+	t.Parallel()
+	for _, tt := range messageParamsTests {
+		err := tt.p.Validate()
+		verr, ok := err.(*ValidationError)
+		if !ok {
+			t.Errorf("%s: expected a *ValidationError, got %v", tt.name, err)
+			continue
+		}
+		if verr.Field != tt.field || verr.Code != tt.code {
+			t.Errorf("%s: expected error for %s (%d), got %s (%d): %v", tt.name, tt.field, tt.code, verr.Field, verr.Code, verr)
+		}
+	}
+}
+
+func TestMessageParamsValues(t *testing.T) {
+	t.Parallel()
+	cat, _ := url.Parse("https://example.com/cat.jpg")
+	dog, _ := url.Parse("https://example.com/dog.jpg")
+	cb, _ := url.Parse("https://example.com/status")
+	p := &MessageParams{
+		To:                  "(925) 271-7005",
+		From:                "Acme Inc",
+		MessagingServiceSid: "MG123",
+		Body:                "hello",
+		MediaURLs:           []*url.URL{cat, dog},
+		StatusCallback:      cb,
+		MaxPrice:            "0.05",
+		ProvideFeedback:     true,
+		ValidityPeriod:      time.Hour,
+		Extra:               url.Values{"SmartEncoded": []string{"true"}},
+	}
+	v, err := p.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := url.Values{
+		"To":                  []string{"+19252717005"},
+		"From":                []string{"Acme Inc"},
+		"MessagingServiceSid": []string{"MG123"},
+		"Body":                []string{"hello"},
+		"MediaUrl":            []string{cat.String(), dog.String()},
+		"StatusCallback":      []string{cb.String()},
+		"MaxPrice":            []string{"0.05"},
+		"ProvideFeedback":     []string{"true"},
+		"ValidityPeriod":      []string{"3600"},
+		"SmartEncoded":        []string{"true"},
+	}
+	if v.Encode() != want.Encode() {
+		t.Errorf("expected %s, got %s", want.Encode(), v.Encode())
+	}
+	for _, sender := range []string{"12345", "+14105551234"} {
+		p := &MessageParams{To: to, From: sender, Body: "hi"}
+		if err := p.Validate(); err != nil {
+			t.Errorf("expected %q to be a valid sender, got %v", sender, err)
+		}
+	}
+}
+
+func TestCreateWithParams(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(sendMessageResponse)
+	defer s.Close()
+	msg, err := client.Messages.CreateWithParams(context.Background(), &MessageParams{To: to, From: from, Body: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Sid == "" {
+		t.Errorf("expected a message sid")
+	}
+	_, err = client.Messages.CreateWithParams(context.Background(), &MessageParams{To: to, From: from})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("expected a *ValidationError, got %v", err)
+	}
+	if l := len(s.URLs); l != 1 {
+		t.Errorf("expected 1 request, got %d", l)
+	}
+}
+
+var twimlURL, _ = url.Parse("https://example.com/twiml")
+
+var callParamsTests = []struct {
+	name  string
+	p     CallParams
+	field string
+	code  Code
+}{
+	{"missing to", CallParams{From: from, URL: twimlURL}, "To", CodeNoToNumber},
+	{"bad to", CallParams{To: "sip:", From: from, URL: twimlURL}, "To", CodeInvalidToNumber},
+	{"missing from", CallParams{To: to, URL: twimlURL}, "From", CodeFromNumberRequired},
+	{"sip from", CallParams{To: to, From: "sip:foo@example.com", URL: twimlURL}, "From", CodeInvalidFromNumber},
+	{"no url", CallParams{To: to, From: from}, "Url", CodeInvalidURL},
+	{"url and application", CallParams{To: to, From: from, URL: twimlURL, ApplicationSid: "AP123"}, "ApplicationSid", 0},
+	{"bad application", CallParams{To: to, From: from, ApplicationSid: "MG123"}, "ApplicationSid", CodeInvalidApplicationSid},
+	{"bad fallback", CallParams{To: to, From: from, URL: twimlURL, FallbackURL: &url.URL{Path: "/fallback"}}, "FallbackUrl", CodeInvalidURL},
+	{"bad method", CallParams{To: to, From: from, URL: twimlURL, Method: "PUT"}, "Method", CodeInvalidMethod},
+	{"events without callback", CallParams{To: to, From: from, URL: twimlURL, StatusCallbackEvents: []CallEvent{CallEventRinging}}, "StatusCallbackEvent", 0},
+	{"unknown event", CallParams{To: to, From: from, URL: twimlURL, StatusCallback: twimlURL, StatusCallbackEvents: []CallEvent{"busy"}}, "StatusCallbackEvent", 0},
+	{"bad digits", CallParams{To: to, From: from, URL: twimlURL, SendDigits: "12x"}, "SendDigits", 0},
+	{"long timeout", CallParams{To: to, From: from, URL: twimlURL, Timeout: 11 * time.Minute}, "Timeout", 0},
+	{"bad detection", CallParams{To: to, From: from, URL: twimlURL, MachineDetection: "true"}, "MachineDetection", 0},
+	{"detection timeout alone", CallParams{To: to, From: from, URL: twimlURL, MachineDetectionTimeout: 5 * time.Second}, "MachineDetectionTimeout", 0},
+	{"short detection timeout", CallParams{To: to, From: from, URL: twimlURL, MachineDetection: MachineDetectionEnable, MachineDetectionTimeout: time.Second}, "MachineDetectionTimeout", 0},
+	{"sip auth to number", CallParams{To: to, From: from, URL: twimlURL, SipAuthUsername: "u", SipAuthPassword: "p"}, "SipAuthUsername", 0},
+	{"sip auth no password", CallParams{To: "sip:foo@example.com", From: from, URL: twimlURL, SipAuthUsername: "u"}, "SipAuthUsername", 0},
+}
+
+func TestCallParamsValidation(t *testing.T) {
+	t.Parallel()
+	for _, tt := range callParamsTests {
+		err := tt.p.Validate()
+		verr, ok := err.(*ValidationError)
+		if !ok {
+			t.Errorf("%s: expected a *ValidationError, got %v", tt.name, err)
+			continue
+		}
+		if verr.Field != tt.field || verr.Code != tt.code {
+			t.Errorf("%s: expected error for %s (%d), got %s (%d): %v", tt.name, tt.field, tt.code, verr.Field, verr.Code, verr)
+		}
+	}
+}
+
+func TestCallParamsValues(t *testing.T) {
+	t.Parallel()
+	p := &CallParams{
+		To:                      "sip:alice@example.com",
+		From:                    "925 271 7005",
+		URL:                     twimlURL,
+		Method:                  "GET",
+		StatusCallback:          twimlURL,
+		StatusCallbackEvents:    []CallEvent{CallEventRinging, CallEventCompleted},
+		SendDigits:              "ww1234#",
+		Timeout:                 30 * time.Second,
+		Record:                  true,
+		MachineDetection:        MachineDetectionDetectMessageEnd,
+		MachineDetectionTimeout: 10 * time.Second,
+		SipAuthUsername:         "alice",
+		SipAuthPassword:         "hunter2",
+	}
+	v, err := p.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := url.Values{
+		"To":                      []string{"sip:alice@example.com"},
+		"From":                    []string{"+19252717005"},
+		"Url":                     []string{twimlURL.String()},
+		"Method":                  []string{"GET"},
+		"StatusCallback":          []string{twimlURL.String()},
+		"StatusCallbackEvent":     []string{"ringing", "completed"},
+		"SendDigits":              []string{"ww1234#"},
+		"Timeout":                 []string{"30"},
+		"Record":                  []string{"true"},
+		"MachineDetection":        []string{"DetectMessageEnd"},
+		"MachineDetectionTimeout": []string{"10"},
+		"SipAuthUsername":         []string{"alice"},
+		"SipAuthPassword":         []string{"hunter2"},
+	}
+	if v.Encode() != want.Encode() {
+		t.Errorf("expected %s, got %s", want.Encode(), v.Encode())
+	}
+}
+
+func TestCallCreateWithParams(t *testing.T) {
+	t.Parallel()
+	client, s := getServer(makeCallResponse)
+	defer s.Close()
+	call, err := client.Calls.CreateWithParams(context.Background(), &CallParams{To: to, From: from, ApplicationSid: "AP123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Sid == "" {
+		t.Errorf("expected a call sid")
+	}
+	_, err = client.Calls.CreateWithParams(context.Background(), &CallParams{To: to, From: from})
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("expected a *ValidationError, got %v", err)
+	}
+	if l := len(s.URLs); l != 1 {
+		t.Errorf("expected 1 request, got %d", l)
+	}
+}
@@ -1,7 +1,9 @@
 package twilio
 
 import (
+	"fmt"
 	"net/url"
+	"time"
 
 	types "github.com/kevinburke/go-types"
 	"golang.org/x/net/context"
@@ -77,9 +79,17 @@ func (n *NumberPurchasingService) Create(ctx context.Context, data url.Values) (
 
 // BuyNumber attempts to buy the provided phoneNumber and returns it if
 // successful.
+//
+// Deprecated: Use BuyNumberContext, which accepts a Context.
 func (ipn *IncomingNumberService) BuyNumber(phoneNumber string) (*IncomingPhoneNumber, error) {
+	return ipn.BuyNumberContext(context.Background(), phoneNumber)
+}
+
+// BuyNumberContext attempts to buy the provided phoneNumber and returns it if
+// successful.
+func (ipn *IncomingNumberService) BuyNumberContext(ctx context.Context, phoneNumber string) (*IncomingPhoneNumber, error) {
 	data := url.Values{"PhoneNumber": []string{phoneNumber}}
-	return ipn.NumberPurchasingService.Create(context.Background(), data)
+	return ipn.NumberPurchasingService.Create(ctx, data)
 }
 
 // Get retrieves a single IncomingPhoneNumber.
@@ -102,6 +112,31 @@ func (ins *IncomingNumberService) GetPage(ctx context.Context, data url.Values)
 
 type IncomingPhoneNumberPageIterator struct {
 	p *PageIterator
+	// start and end are the bounds of a GetIncomingNumbersInRange iterator, or
+	// the zero time otherwise.
+	start time.Time
+	end   time.Time
+}
+
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *IncomingPhoneNumberPageIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *IncomingPhoneNumberPageIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (c *IncomingPhoneNumberPageIterator) Cursor() Cursor {
+	cur := c.p.Cursor()
+	cur.Start = c.start
+	cur.End = c.end
+	return cur
 }
 
 // GetPageIterator returns an iterator which can be used to retrieve pages.
@@ -112,9 +147,29 @@ func (c *IncomingNumberService) GetPageIterator(data url.Values) *IncomingPhoneN
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the
+// Cursor of an earlier iterator.
+func (c *IncomingNumberService) ResumePageIterator(cur Cursor) *IncomingPhoneNumberPageIterator {
+	iter := ResumePageIterator(c.client, numbersPathPart, cur)
+	return &IncomingPhoneNumberPageIterator{
+		p:     iter,
+		start: cur.Start,
+		end:   cur.End,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
-// NoMoreResults is returned.
+// NoMoreResults is returned. If the iterator was returned by
+// GetIncomingNumbersInRange, Next may fetch several pages to find one with
+// results in the range.
 func (c *IncomingPhoneNumberPageIterator) Next(ctx context.Context) (*IncomingPhoneNumberPage, error) {
+	if !c.end.IsZero() {
+		page, err := nextPageInRange(ctx, c.p, c.start, c.end, false, func() datedPage { return new(IncomingPhoneNumberPage) })
+		if err != nil {
+			return nil, err
+		}
+		return page.(*IncomingPhoneNumberPage), nil
+	}
 	cp := new(IncomingPhoneNumberPage)
 	err := c.p.Next(ctx, cp)
 	if err != nil {
@@ -123,3 +178,91 @@ func (c *IncomingPhoneNumberPageIterator) Next(ctx context.Context) (*IncomingPh
 	c.p.SetNextPageURI(cp.NextPageURI)
 	return cp, nil
 }
+
+// GetIncomingNumbersInRange returns an iterator over the phone numbers created
+// in the range [start, end), optionally further filtered by data. start and end
+// may have any precision; phone numbers outside the range are removed from each
+// page. GetIncomingNumbersInRange panics if start is after end. If you have an
+// end, but don't want to specify a start, use twilio.Epoch for start. If you
+// have a start, but don't want to specify an end, use twilio.HeatDeath for end.
+// The API can't filter phone numbers by date, and doesn't document the order
+// they're returned in, so this pages through every phone number, filtering as
+// it goes.
+// Returned pages have at most PageSize results, but may have fewer.
+func (c *IncomingNumberService) GetIncomingNumbersInRange(start time.Time, end time.Time, data url.Values) *IncomingPhoneNumberPageIterator {
+	if start.After(end) {
+		panic("start date is after end date")
+	}
+	d := url.Values{}
+	for k, v := range data {
+		d[k] = v
+	}
+	d.Del("Page") // just in case
+	return &IncomingPhoneNumberPageIterator{
+		p:     NewPageIterator(c.client, d, numbersPathPart),
+		start: start,
+		end:   end,
+	}
+}
+
+func (p *IncomingPhoneNumberPage) dates() ([]time.Time, error) {
+	times := make([]time.Time, len(p.IncomingPhoneNumbers))
+	for i, incomingPhoneNumber := range p.IncomingPhoneNumbers {
+		if !incomingPhoneNumber.DateCreated.Valid {
+			return nil, fmt.Errorf("Couldn't verify the date of phone number: %#v", incomingPhoneNumber)
+		}
+		times[i] = incomingPhoneNumber.DateCreated.Time
+	}
+	return times, nil
+}
+
+func (p *IncomingPhoneNumberPage) removeIndexes(indexes []int) {
+	// reverse order so we don't delete the wrong index
+	for i := len(indexes) - 1; i >= 0; i-- {
+		p.IncomingPhoneNumbers = append(p.IncomingPhoneNumbers[:indexes[i]], p.IncomingPhoneNumbers[indexes[i]+1:]...)
+	}
+}
+
+// IncomingPhoneNumberIterator yields IncomingPhoneNumbers one at a time. Call
+// Next to advance the iterator, and Value to get the current
+// IncomingPhoneNumber.
+type IncomingPhoneNumberIterator struct {
+	*ItemIterator
+}
+
+// NewIncomingPhoneNumberIterator returns a IncomingPhoneNumberIterator that
+// yields each IncomingPhoneNumber in the pages returned by p.
+func NewIncomingPhoneNumberIterator(p *IncomingPhoneNumberPageIterator) *IncomingPhoneNumberIterator {
+	return &IncomingPhoneNumberIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.IncomingPhoneNumbers))
+		for i := range page.IncomingPhoneNumbers {
+			items[i] = page.IncomingPhoneNumbers[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current IncomingPhoneNumber, or nil if Next hasn't been
+// called or returned false.
+func (i *IncomingPhoneNumberIterator) Value() *IncomingPhoneNumber {
+	v, _ := i.ItemIterator.Value().(*IncomingPhoneNumber)
+	return v
+}
+
+// GetIterator returns an iterator over the IncomingPhoneNumbers matching the
+// filters in data, fetching new pages as needed.
+func (c *IncomingNumberService) GetIterator(data url.Values) *IncomingPhoneNumberIterator {
+	return NewIncomingPhoneNumberIterator(c.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (c *IncomingNumberService) ResumeIterator(cur Cursor) *IncomingPhoneNumberIterator {
+	iter := NewIncomingPhoneNumberIterator(c.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
@@ -5,7 +5,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/kevinburke/rest"
 	"golang.org/x/net/context"
 )
 
@@ -35,15 +34,15 @@ func TestBuyNumber(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected to get an error, got nil")
 	}
-	rerr, ok := err.(*rest.Error)
+	terr, ok := err.(*Error)
 	if !ok {
-		t.Fatal("couldn't cast err to a rest.Error")
+		t.Fatal("couldn't cast err to an Error")
 	}
 	expected := "+1foobar is not a valid number"
-	if rerr.Title != expected {
-		t.Errorf("expected Title to be %s, got %s", expected, rerr.Title)
+	if terr.Message != expected {
+		t.Errorf("expected Message to be %s, got %s", expected, terr.Message)
 	}
-	if rerr.StatusCode != 400 {
-		t.Errorf("expected StatusCode to be 400, got %d", rerr.StatusCode)
+	if terr.StatusCode != 400 {
+		t.Errorf("expected StatusCode to be 400, got %d", terr.StatusCode)
 	}
 }
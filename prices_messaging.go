@@ -52,3 +52,26 @@ func (cmps *CountryMessagingPriceService) GetPageIterator(data url.Values) *Coun
 		p: iter,
 	}
 }
+
+// ResumePageIterator returns an iterator that continues from cur, the
+// Cursor of an earlier iterator.
+func (cmps *CountryMessagingPriceService) ResumePageIterator(cur Cursor) *CountryPricePageIterator {
+	iter := ResumePageIterator(cmps.client, messagingPathPart+"/Countries", cur)
+	return &CountryPricePageIterator{
+		p: iter,
+	}
+}
+
+// GetIterator returns an iterator over the countries matching the filters in
+// data, fetching new pages as needed.
+func (cmps *CountryMessagingPriceService) GetIterator(data url.Values) *PriceCountryIterator {
+	return NewPriceCountryIterator(cmps.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (cmps *CountryMessagingPriceService) ResumeIterator(cur Cursor) *PriceCountryIterator {
+	iter := NewPriceCountryIterator(cmps.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
@@ -57,6 +57,24 @@ type CountryPricePageIterator struct {
 	p *PageIterator
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *CountryPricePageIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *CountryPricePageIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (c *CountryPricePageIterator) Cursor() Cursor {
+	return c.p.Cursor()
+}
+
 // GetPageIterator returns an iterator which can be used to retrieve pages.
 func (cpnps *CountryPhoneNumberPriceService) GetPageIterator(data url.Values) *CountryPricePageIterator {
 	iter := NewPageIterator(cpnps.client, data, phoneNumbersPathPart+"/Countries")
@@ -65,6 +83,15 @@ func (cpnps *CountryPhoneNumberPriceService) GetPageIterator(data url.Values) *C
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the
+// Cursor of an earlier iterator.
+func (cpnps *CountryPhoneNumberPriceService) ResumePageIterator(cur Cursor) *CountryPricePageIterator {
+	iter := ResumePageIterator(cpnps.client, phoneNumbersPathPart+"/Countries", cur)
+	return &CountryPricePageIterator{
+		p: iter,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
 // NoMoreResults is returned.
 func (c *CountryPricePageIterator) Next(ctx context.Context) (*CountriesPricePage, error) {
@@ -76,3 +103,46 @@ func (c *CountryPricePageIterator) Next(ctx context.Context) (*CountriesPricePag
 	c.p.SetNextPageURI(cp.Meta.NextPageURL)
 	return cp, nil
 }
+
+// PriceCountryIterator yields PriceCountries one at a time. Call Next to
+// advance the iterator, and Value to get the current PriceCountry.
+type PriceCountryIterator struct {
+	*ItemIterator
+}
+
+// NewPriceCountryIterator returns a PriceCountryIterator that yields each
+// PriceCountry in the pages returned by p.
+func NewPriceCountryIterator(p *CountryPricePageIterator) *PriceCountryIterator {
+	return &PriceCountryIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.Countries))
+		for i := range page.Countries {
+			items[i] = page.Countries[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current PriceCountry, or nil if Next hasn't been called or
+// returned false.
+func (p *PriceCountryIterator) Value() *PriceCountry {
+	v, _ := p.ItemIterator.Value().(*PriceCountry)
+	return v
+}
+
+// GetIterator returns an iterator over the countries matching the filters in
+// data, fetching new pages as needed.
+func (cpnps *CountryPhoneNumberPriceService) GetIterator(data url.Values) *PriceCountryIterator {
+	return NewPriceCountryIterator(cpnps.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (cpnps *CountryPhoneNumberPriceService) ResumeIterator(cur Cursor) *PriceCountryIterator {
+	iter := NewPriceCountryIterator(cpnps.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
@@ -85,3 +85,26 @@ func (cvps *CountryVoicePriceService) GetPageIterator(data url.Values) *CountryP
 		p: iter,
 	}
 }
+
+// ResumePageIterator returns an iterator that continues from cur, the
+// Cursor of an earlier iterator.
+func (cvps *CountryVoicePriceService) ResumePageIterator(cur Cursor) *CountryPricePageIterator {
+	iter := ResumePageIterator(cvps.client, voicePathPart+"/Countries", cur)
+	return &CountryPricePageIterator{
+		p: iter,
+	}
+}
+
+// GetIterator returns an iterator over the countries matching the filters in
+// data, fetching new pages as needed.
+func (cvps *CountryVoicePriceService) GetIterator(data url.Values) *PriceCountryIterator {
+	return NewPriceCountryIterator(cvps.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (cvps *CountryVoicePriceService) ResumeIterator(cur Cursor) *PriceCountryIterator {
+	iter := NewPriceCountryIterator(cvps.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
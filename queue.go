@@ -59,6 +59,24 @@ type QueuePageIterator struct {
 	p *PageIterator
 }
 
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *QueuePageIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *QueuePageIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (c *QueuePageIterator) Cursor() Cursor {
+	return c.p.Cursor()
+}
+
 // GetPageIterator returns a QueuePageIterator with the given page filters.
 // Call iterator.Next() to get the first page of resources (and again to
 // retrieve subsequent pages).
@@ -69,6 +87,15 @@ func (c *QueueService) GetPageIterator(data url.Values) *QueuePageIterator {
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the
+// Cursor of an earlier iterator.
+func (c *QueueService) ResumePageIterator(cur Cursor) *QueuePageIterator {
+	iter := ResumePageIterator(c.client, queuePathPart, cur)
+	return &QueuePageIterator{
+		p: iter,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
 // NoMoreResults is returned.
 func (c *QueuePageIterator) Next(ctx context.Context) (*QueuePage, error) {
@@ -80,3 +107,46 @@ func (c *QueuePageIterator) Next(ctx context.Context) (*QueuePage, error) {
 	c.p.SetNextPageURI(qp.NextPageURI)
 	return qp, nil
 }
+
+// QueueIterator yields Queues one at a time. Call Next to advance the iterator,
+// and Value to get the current Queue.
+type QueueIterator struct {
+	*ItemIterator
+}
+
+// NewQueueIterator returns a QueueIterator that yields each Queue in the pages
+// returned by p.
+func NewQueueIterator(p *QueuePageIterator) *QueueIterator {
+	return &QueueIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.Queues))
+		for i := range page.Queues {
+			items[i] = page.Queues[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current Queue, or nil if Next hasn't been called or
+// returned false.
+func (q *QueueIterator) Value() *Queue {
+	v, _ := q.ItemIterator.Value().(*Queue)
+	return v
+}
+
+// GetIterator returns an iterator over the Queues matching the filters in
+// data, fetching new pages as needed.
+func (c *QueueService) GetIterator(data url.Values) *QueueIterator {
+	return NewQueueIterator(c.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (c *QueueService) ResumeIterator(cur Cursor) *QueueIterator {
+	iter := NewQueueIterator(c.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
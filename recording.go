@@ -1,8 +1,14 @@
 package twilio
 
 import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/net/context"
 )
@@ -31,6 +37,9 @@ type Recording struct {
 // URL returns the URL that can be used to play this recording, based on the
 // extension. No error is returned if you provide an invalid extension. As of
 // October 2016, the valid values are ".wav" and ".mp3".
+//
+// The URL always points at BaseURL. To fetch the audio from the Client's base
+// URL, with authentication, use RecordingService.Download.
 func (r *Recording) URL(extension string) string {
 	if !strings.HasPrefix(extension, ".") {
 		extension = "." + extension
@@ -67,6 +76,119 @@ func (r *RecordingService) Delete(ctx context.Context, sid string) error {
 	return r.client.DeleteResource(ctx, recordingsPathPart, sid)
 }
 
+// Download returns the audio for the Recording with the given sid, in the
+// given format: "wav" or "mp3", with or without a leading ".". The request
+// uses the Client's base URL and credentials, and its Limiter, Hooks and
+// Logger; it isn't retried. The caller must close the body, which also frees
+// the request's Limiter slot.
+func (r *RecordingService) Download(ctx context.Context, sid string, format string) (io.ReadCloser, error) {
+	return r.DownloadRange(ctx, sid, format, 0, -1)
+}
+
+// DownloadRange is like Download, but only returns length bytes of the audio,
+// starting at offset. If length is negative, the audio is read to the end.
+func (r *RecordingService) DownloadRange(ctx context.Context, sid string, format string, offset int64, length int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("twilio: Invalid offset %d", offset)
+	}
+	if length == 0 {
+		return ioutil.NopCloser(strings.NewReader("")), nil
+	}
+	if !strings.HasPrefix(format, ".") {
+		format = "." + format
+	}
+	// FullPath adds a .json extension, which we don't want.
+	path := strings.TrimSuffix(r.client.FullPath(recordingsPathPart+"/"+sid), ".json") + format
+	req, err := r.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = withContext(req, ctx)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Del("Accept")
+	switch {
+	case length > 0:
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	case offset > 0:
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	release, err := r.client.wait(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	// The request holds its Limiter slot until the caller closes the body.
+	httpClient := r.client.Client.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	r.client.beforeRequest(ctx, req)
+	r.client.logRequest(ctx, req, nil)
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		r.client.logResponse(ctx, req, nil, nil, err, start, 1)
+		r.client.afterResponse(ctx, req, nil, err, start, 1)
+		release()
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		// Cap the error body, but keep the original Closer so the connection
+		// is released.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(resp.Body, 64*1024), resp.Body}
+		err = parseTwilioError(resp)
+	}
+	// Don't log the audio.
+	r.client.logResponse(ctx, req, resp, nil, err, start, 1)
+	r.client.afterResponse(ctx, req, resp, err, start, 1)
+	if err != nil {
+		resp.Body.Close()
+		release()
+		return nil, err
+	}
+	var body io.Reader = resp.Body
+	if resp.StatusCode != http.StatusPartialContent && offset > 0 {
+		// The server ignored the Range header and sent the whole file.
+		if _, err := io.CopyN(ioutil.Discard, body, offset); err != nil {
+			resp.Body.Close()
+			release()
+			return nil, err
+		}
+	}
+	if length > 0 {
+		body = io.LimitReader(body, length)
+	}
+	return &readCloser{Reader: body, Closer: resp.Body, release: release}, nil
+}
+
+// DownloadTo writes the audio for the Recording with the given sid to w, in
+// the given format, and returns the number of bytes written. See Download.
+func (r *RecordingService) DownloadTo(ctx context.Context, sid string, format string, w io.Writer) (int64, error) {
+	body, err := r.Download(ctx, sid, format)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	return io.Copy(w, body)
+}
+
+// readCloser releases the request's Limiter slot the first time it's
+// closed.
+type readCloser struct {
+	io.Reader
+	io.Closer
+	once    sync.Once
+	release func()
+}
+
+func (r *readCloser) Close() error {
+	err := r.Closer.Close()
+	r.once.Do(r.release)
+	return err
+}
+
 func (r *RecordingService) GetPage(ctx context.Context, data url.Values) (*RecordingPage, error) {
 	iter := r.GetPageIterator(data)
 	return iter.Next(ctx)
@@ -83,6 +205,31 @@ func (r *RecordingService) GetTranscriptions(ctx context.Context, recordingSid s
 
 type RecordingPageIterator struct {
 	p *PageIterator
+	// start and end are the bounds of a GetRecordingsInRange iterator, or the
+	// zero time otherwise.
+	start time.Time
+	end   time.Time
+}
+
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (r *RecordingPageIterator) Prefetch(depth int) {
+	r.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (r *RecordingPageIterator) Stop() {
+	r.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (r *RecordingPageIterator) Cursor() Cursor {
+	cur := r.p.Cursor()
+	cur.Start = r.start
+	cur.End = r.end
+	return cur
 }
 
 // GetPageIterator returns an iterator which can be used to retrieve pages.
@@ -93,9 +240,29 @@ func (r *RecordingService) GetPageIterator(data url.Values) *RecordingPageIterat
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the
+// Cursor of an earlier iterator.
+func (r *RecordingService) ResumePageIterator(cur Cursor) *RecordingPageIterator {
+	iter := ResumePageIterator(r.client, recordingsPathPart, cur)
+	return &RecordingPageIterator{
+		p:     iter,
+		start: cur.Start,
+		end:   cur.End,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
-// NoMoreResults is returned.
+// NoMoreResults is returned. If the iterator was returned by
+// GetRecordingsInRange, Next may fetch several pages to find one with results
+// in the range.
 func (r *RecordingPageIterator) Next(ctx context.Context) (*RecordingPage, error) {
+	if !r.end.IsZero() {
+		page, err := nextPageInRange(ctx, r.p, r.start, r.end, true, func() datedPage { return new(RecordingPage) })
+		if err != nil {
+			return nil, err
+		}
+		return page.(*RecordingPage), nil
+	}
 	rp := new(RecordingPage)
 	err := r.p.Next(ctx, rp)
 	if err != nil {
@@ -104,3 +271,109 @@ func (r *RecordingPageIterator) Next(ctx context.Context) (*RecordingPage, error
 	r.p.SetNextPageURI(rp.NextPageURI)
 	return rp, nil
 }
+
+// GetRecordingsInRange returns an iterator over the recordings created in the
+// range [start, end), optionally further filtered by data. start and end may
+// have any precision; recordings outside the range are removed from each page.
+// GetRecordingsInRange panics if start is after end. If you have an end, but
+// don't want to specify a start, use twilio.Epoch for start. If you have a
+// start, but don't want to specify an end, use twilio.HeatDeath for end. Any
+// DateCreated filters in data are ignored.
+//
+// Assumes that Twilio returns resources in chronological order, latest first.
+// Returned pages have at most PageSize results, but may have fewer.
+func (r *RecordingService) GetRecordingsInRange(start time.Time, end time.Time, data url.Values) *RecordingPageIterator {
+	d := rangeFilters(data, "DateCreated", start, end)
+	return &RecordingPageIterator{
+		p:     NewPageIterator(r.client, d, recordingsPathPart),
+		start: start,
+		end:   end,
+	}
+}
+
+func (p *RecordingPage) dates() ([]time.Time, error) {
+	times := make([]time.Time, len(p.Recordings))
+	for i, recording := range p.Recordings {
+		if !recording.DateCreated.Valid {
+			return nil, fmt.Errorf("Couldn't verify the date of recording: %#v", recording)
+		}
+		times[i] = recording.DateCreated.Time
+	}
+	return times, nil
+}
+
+func (p *RecordingPage) removeIndexes(indexes []int) {
+	// reverse order so we don't delete the wrong index
+	for i := len(indexes) - 1; i >= 0; i-- {
+		p.Recordings = append(p.Recordings[:indexes[i]], p.Recordings[indexes[i]+1:]...)
+	}
+}
+
+// RecordingIterator yields Recordings one at a time. Call Next to advance the
+// iterator, and Value to get the current Recording.
+type RecordingIterator struct {
+	*ItemIterator
+}
+
+// NewRecordingIterator returns a RecordingIterator that yields each Recording
+// in the pages returned by p.
+func NewRecordingIterator(p *RecordingPageIterator) *RecordingIterator {
+	return &RecordingIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.Recordings))
+		for i := range page.Recordings {
+			items[i] = page.Recordings[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current Recording, or nil if Next hasn't been called or
+// returned false.
+func (r *RecordingIterator) Value() *Recording {
+	v, _ := r.ItemIterator.Value().(*Recording)
+	return v
+}
+
+// GetIterator returns an iterator over the Recordings matching the filters in
+// data, fetching new pages as needed.
+func (r *RecordingService) GetIterator(data url.Values) *RecordingIterator {
+	return NewRecordingIterator(r.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (r *RecordingService) ResumeIterator(cur Cursor) *RecordingIterator {
+	iter := NewRecordingIterator(r.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
+
+// Stream sends the recordings matching the filters in data to the returned channel,
+// fetching new pages as needed. If the caller doesn't keep up, Stream waits
+// for it before fetching more pages. The recordings channel is closed when there
+// are no more recordings, an error occurs, or ctx is cancelled; then the error
+// channel receives the error (or nil) and is closed. To stop early, cancel
+// ctx.
+func (r *RecordingService) Stream(ctx context.Context, data url.Values) (<-chan *Recording, <-chan error) {
+	recordings := make(chan *Recording, streamBufferSize)
+	errs := make(chan error, 1)
+	iter := r.GetIterator(data)
+	go func() {
+		err := iter.stream(ctx, func(v interface{}) bool {
+			select {
+			case recordings <- v.(*Recording):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		close(recordings)
+		errs <- err
+		close(errs)
+	}()
+	return recordings, errs
+}
@@ -1,6 +1,11 @@
 package twilio
 
 import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,3 +28,122 @@ func TestGetRecording(t *testing.T) {
 		t.Errorf("expected Sid to equal %s, got %s", sid, recording.Sid)
 	}
 }
+
+func TestDownloadRangeIgnored(t *testing.T) {
+	t.Parallel()
+	var path, rangeHeader string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		rangeHeader = r.Header.Get("Range")
+		// ignore the Range header
+		w.Write([]byte("0123456789"))
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	var statuses []int
+	client.AddHook(&Hook{AfterResponse: func(ctx context.Context, req *http.Request, info *ResponseInfo) {
+		statuses = append(statuses, info.StatusCode)
+	}})
+	body, err := client.Recordings.DownloadRange(context.Background(), "RE123", "mp3", 3, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "3456" {
+		t.Errorf("expected 3456, got %q", data)
+	}
+	if path != "/2010-04-01/Accounts/AC123/Recordings/RE123.mp3" || rangeHeader != "bytes=3-6" {
+		t.Errorf("bad request: %s Range: %s", path, rangeHeader)
+	}
+	if len(statuses) != 1 || statuses[0] != 200 {
+		t.Errorf("expected the hook to be called once, got %v", statuses)
+	}
+}
+
+func TestDownloadHoldsLimiterUntilClose(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/2010-04-01/Accounts/AC123/Recordings/REmissing.wav" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(404)
+			w.Write([]byte(`{"code": 20404, "message": "The requested resource was not found", "status": 404}`))
+			return
+		}
+		w.Write([]byte("0123456789"))
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	l := NewLimiter(0, 0, 1)
+	client.SetLimiter(l)
+	slotFree := func() bool {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		release, err := l.Wait(ctx, "Recordings")
+		if err != nil {
+			return false
+		}
+		release()
+		return true
+	}
+	body, err := client.Recordings.Download(context.Background(), "RE123", "wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if slotFree() {
+		t.Errorf("expected the Limiter slot to be held until the body is closed")
+	}
+	if _, err := ioutil.ReadAll(body); err != nil {
+		t.Fatal(err)
+	}
+	body.Close()
+	body.Close()
+	if !slotFree() {
+		t.Errorf("expected closing the body to free the Limiter slot")
+	}
+	if _, err := client.Recordings.Download(context.Background(), "REmissing", "wav"); err == nil {
+		t.Fatal("expected an error for a missing recording")
+	}
+	if !slotFree() {
+		t.Errorf("expected a failed download to free the Limiter slot")
+	}
+}
+
+// trackedBody records whether it was closed.
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *trackedBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+func errorResponse(r *http.Request, body io.ReadCloser) *http.Response {
+	return &http.Response{
+		StatusCode: 404,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       body,
+		Request:    r,
+	}
+}
+
+func TestDownloadClosesErrorBody(t *testing.T) {
+	t.Parallel()
+	body := &trackedBody{Reader: strings.NewReader(`{"code": 20404, "message": "Not found", "status": 404}`)}
+	client := NewClient("AC123", "456", &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return errorResponse(r, body), nil
+	})})
+	if _, err := client.Recordings.Download(context.Background(), "RE123", "wav"); !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if !body.closed {
+		t.Errorf("expected the response body to be closed")
+	}
+}
@@ -0,0 +1,137 @@
+package twilio
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// A MediaResolver decides how MediaService.GetURL follows the redirects from
+// the Twilio API to the URL where a Media resource's content is stored.
+//
+// GetURL requests the Media resource and reads the Location header of the
+// response. If the resolver accepts that URL, it's returned; otherwise GetURL
+// requests it and tries again with the next Location.
+type MediaResolver struct {
+	// MaxRedirects is the most redirects GetURL follows looking for an
+	// allowed URL. If zero, 5 are allowed.
+	MaxRedirects int
+	// AllowedHosts lists the hosts that store Media content. A URL on one of
+	// these hosts is returned instead of being followed. An entry that begins
+	// with a "." matches any subdomain, so ".amazonaws.com" matches
+	// "s3-external-1.amazonaws.com". Other entries must match the host
+	// exactly, with or without its port. If AllowedHosts is empty, any host
+	// is allowed, so the first redirect is returned.
+	AllowedHosts []string
+	// UpgradeHTTPS rewrites an allowed URL with the http scheme to use https.
+	// Media.Download refuses to download over plain HTTP.
+	UpgradeHTTPS bool
+	// Rewrite, if set, is called with each allowed URL, and may modify it
+	// before it's returned, for example to switch to a different CDN. It runs
+	// before UpgradeHTTPS.
+	Rewrite func(*url.URL) (*url.URL, error)
+	// FirstRedirect returns the Location of the response from the Twilio API,
+	// without checking it against AllowedHosts. Rewrite and UpgradeHTTPS are
+	// still applied.
+	FirstRedirect bool
+}
+
+// DefaultMediaResolver is the MediaResolver used by a Client without one. As
+// of October 2016, the Twilio API redirects to media.twiliocdn.com, which
+// redirects to S3. Twilio gives us a virtual-hosted style S3 URL, which uses
+// HTTP; it's rewritten to the equivalent path-style HTTPS URL.
+var DefaultMediaResolver = &MediaResolver{
+	MaxRedirects: 5,
+	AllowedHosts: []string{".amazonaws.com"},
+	Rewrite:      rewriteS3URL,
+	UpgradeHTTPS: true,
+}
+
+// MediaResolveError is returned by MediaService.GetURL when it can't find an
+// allowed URL for a Media resource.
+type MediaResolveError struct {
+	// URL is the last URL that was requested.
+	URL string
+	// Redirects is the number of redirects that were followed.
+	Redirects int
+	Reason    string
+}
+
+func (e *MediaResolveError) Error() string {
+	return fmt.Sprintf("twilio: Couldn't resolve media URL %s after %d redirects: %s", e.URL, e.Redirects, e.Reason)
+}
+
+// resolve checks location, the Location of the response to a request for
+// urlStr. If done is true, u is the URL GetURL should return; otherwise, u
+// should be requested next.
+func (r *MediaResolver) resolve(urlStr string, location string, redirects int) (u *url.URL, done bool, err error) {
+	if location == "" {
+		return nil, false, &MediaResolveError{URL: urlStr, Redirects: redirects, Reason: "response had no Location header"}
+	}
+	base, err := url.Parse(urlStr)
+	if err != nil {
+		return nil, false, err
+	}
+	u, err = base.Parse(location)
+	if err != nil {
+		return nil, false, &MediaResolveError{URL: urlStr, Redirects: redirects, Reason: err.Error()}
+	}
+	if !r.FirstRedirect && !r.allowed(u) {
+		max := r.MaxRedirects
+		if max <= 0 {
+			max = 5
+		}
+		if redirects >= max {
+			return nil, false, &MediaResolveError{URL: urlStr, Redirects: redirects, Reason: "too many redirects"}
+		}
+		return u, false, nil
+	}
+	if r.Rewrite != nil {
+		u, err = r.Rewrite(u)
+		if err != nil {
+			return nil, false, &MediaResolveError{URL: location, Redirects: redirects, Reason: err.Error()}
+		}
+	}
+	if r.UpgradeHTTPS && u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+	return u, true, nil
+}
+
+func (r *MediaResolver) allowed(u *url.URL) bool {
+	if len(r.AllowedHosts) == 0 {
+		return true
+	}
+	hostname := u.Host
+	if h, _, err := net.SplitHostPort(u.Host); err == nil {
+		hostname = h
+	}
+	for _, host := range r.AllowedHosts {
+		if strings.HasPrefix(host, ".") {
+			if strings.HasSuffix(hostname, host) {
+				return true
+			}
+			continue
+		}
+		if host == u.Host || host == hostname {
+			return true
+		}
+	}
+	return false
+}
+
+// rewriteS3URL rewrites the virtual-hosted style URL of a file in the
+// media.twiliocdn.com bucket to the path-style URL, which can be downloaded
+// over HTTPS.
+//
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/UsingBucket.html
+func rewriteS3URL(u *url.URL) (*url.URL, error) {
+	const bucket = "media.twiliocdn.com"
+	if strings.HasPrefix(u.Host, bucket+".") {
+		u.Host = strings.TrimPrefix(u.Host, bucket+".")
+		u.Path = "/" + bucket + u.Path
+		u.Scheme = "https"
+	}
+	return u, nil
+}
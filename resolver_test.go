@@ -0,0 +1,99 @@
+package twilio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestDefaultMediaResolver(t *testing.T) {
+	t.Parallel()
+	base := "https://api.twilio.com/2010-04-01/Accounts/AC123/Messages/MM123/Media/ME123"
+	for _, tt := range []struct {
+		location string
+		want     string
+		done     bool
+	}{
+		{"https://s3-external-1.amazonaws.com/media.twiliocdn.com/AC123/abc", "https://s3-external-1.amazonaws.com/media.twiliocdn.com/AC123/abc", true},
+		{"http://media.twiliocdn.com.s3.amazonaws.com/AC123/abc", "https://s3.amazonaws.com/media.twiliocdn.com/AC123/abc", true},
+		{"https://media.twiliocdn.com/AC123/abc", "https://media.twiliocdn.com/AC123/abc", false},
+		{"/2010-04-01/Accounts/AC123/Media/ME123", "https://api.twilio.com/2010-04-01/Accounts/AC123/Media/ME123", false},
+	} {
+		u, done, err := DefaultMediaResolver.resolve(base, tt.location, 0)
+		if err != nil {
+			t.Errorf("%s: %v", tt.location, err)
+			continue
+		}
+		if u.String() != tt.want || done != tt.done {
+			t.Errorf("%s: got %s %t, want %s %t", tt.location, u, done, tt.want, tt.done)
+		}
+	}
+	if _, _, err := DefaultMediaResolver.resolve(base, "", 0); err == nil {
+		t.Error("expected an error for a missing Location")
+	}
+	_, _, err := DefaultMediaResolver.resolve(base, "https://media.twiliocdn.com/AC123/abc", 5)
+	rerr, ok := err.(*MediaResolveError)
+	if !ok {
+		t.Fatalf("expected a MediaResolveError, got %v", err)
+	}
+	if rerr.Redirects != 5 || rerr.URL != base {
+		t.Errorf("bad error: %#v", rerr)
+	}
+}
+
+func TestGetURLResolver(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2010-04-01/Accounts/AC123/Messages/MM123/Media/ME123", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/cdn/ME123", http.StatusFound)
+	})
+	mux.HandleFunc("/cdn/ME123", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/storage/ME123", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/ME123", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/storage/ME123", http.StatusFound)
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+	host, _ := url.Parse(s.URL)
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	ctx := context.Background()
+
+	client.MediaResolver = &MediaResolver{FirstRedirect: true, AllowedHosts: []string{"example.com"}}
+	u, err := client.Media.GetURL(ctx, "MM123", "ME123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.String() != s.URL+"/cdn/ME123" {
+		t.Errorf("expected the first redirect, got %s", u)
+	}
+
+	client.MediaResolver = &MediaResolver{
+		AllowedHosts: []string{host.Host},
+		Rewrite: func(u *url.URL) (*url.URL, error) {
+			u.Path = "/rewritten" + u.Path
+			return u, nil
+		},
+	}
+	u, err = client.Media.GetURL(ctx, "MM123", "ME123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.String() != s.URL+"/rewritten/cdn/ME123" {
+		t.Errorf("expected a rewritten URL, got %s", u)
+	}
+
+	client.MediaResolver = &MediaResolver{AllowedHosts: []string{"example.com"}, MaxRedirects: 3}
+	_, err = client.Media.GetURL(ctx, "MM123", "ME123")
+	rerr, ok := err.(*MediaResolveError)
+	if !ok {
+		t.Fatalf("expected a MediaResolveError, got %v", err)
+	}
+	if rerr.Redirects != 3 || rerr.URL != s.URL+"/storage/ME123" {
+		t.Errorf("bad error: %#v", rerr)
+	}
+}
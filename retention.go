@@ -0,0 +1,284 @@
+package twilio
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultSweepConcurrency is the number of recordings Sweep processes at once,
+// unless RetentionOptions.Concurrency is set.
+const DefaultSweepConcurrency = 4
+
+// DefaultSweepBatchSize is the number of recordings Sweep lists before
+// processing them, unless RetentionOptions.BatchSize is set.
+const DefaultSweepBatchSize = 1000
+
+// RetentionOptions configure RecordingService.Sweep.
+type RetentionOptions struct {
+	// Before is required. Recordings created before Before are deleted; for
+	// example, use time.Now().AddDate(0, 0, -90) to keep 90 days.
+	Before time.Time
+	// Filters further restrict which recordings are deleted, for example by
+	// CallSid.
+	Filters url.Values
+	// Archive, if set, is called with each recording, its transcriptions and
+	// its audio before anything is deleted. If Archive returns an error, the
+	// recording and its transcriptions are kept.
+	Archive func(ctx context.Context, rec *Recording, transcriptions []*Transcription, audio io.Reader) error
+	// Format is the audio format passed to Archive, "wav" or "mp3". Defaults
+	// to "wav".
+	Format string
+	// DryRun lists the recordings that would be deleted, without archiving
+	// or deleting anything.
+	DryRun bool
+	// Concurrency is the number of recordings processed at once. Defaults to
+	// DefaultSweepConcurrency.
+	Concurrency int
+	// BatchSize is roughly the number of recordings listed and held in
+	// memory at a time. Defaults to DefaultSweepBatchSize.
+	BatchSize int
+	// MaxRecordings, if positive, is the most recordings a single call to
+	// Sweep will match, so a large backlog can be worked through over several
+	// runs.
+	MaxRecordings int
+}
+
+// A RetentionFailure records a recording that Sweep couldn't archive or
+// delete.
+type RetentionFailure struct {
+	RecordingSid string
+	// TranscriptionSid is set if a transcription couldn't be deleted.
+	TranscriptionSid string
+	Err              error
+}
+
+// A RetentionReport summarizes the work done by Sweep.
+type RetentionReport struct {
+	DryRun bool
+	// Matched is the number of recordings created before the cutoff.
+	Matched int
+	// Archived is the number of recordings passed to Archive successfully.
+	Archived int
+	// Deleted lists the sids of the recordings that were deleted, or, for a
+	// dry run, would have been.
+	Deleted               []string
+	TranscriptionsDeleted int
+	Failed                []*RetentionFailure
+}
+
+var errNoRetentionCutoff = errors.New("twilio: RetentionOptions.Before is required")
+
+// Sweep deletes the recordings created before opts.Before, oldest first, along
+// with their transcriptions. If opts.Archive is set, each recording is archived
+// before it's deleted. A recording is only deleted once it's been archived and
+// all of its transcriptions have been deleted; failures are recorded in the
+// report, and Sweep moves on to the next recording.
+//
+// Twilio lists recordings newest first, so Sweep first pages through the
+// matching recordings to find the days that have any, keeping only the dates.
+// It then lists the recordings a day at a time, oldest day first, and
+// processes them in batches of about opts.BatchSize, oldest first, so a run
+// never holds more than a batch of recordings in memory, and a page that can't
+// be listed doesn't undo the batches before it. If a single day has more than
+// a batch of recordings, its newer batches are processed first.
+//
+// An error is returned if the recordings can't be listed, or ctx is canceled,
+// along with a report of the work done so far.
+func (r *RecordingService) Sweep(ctx context.Context, opts *RetentionOptions) (*RetentionReport, error) {
+	if opts == nil || opts.Before.IsZero() {
+		return nil, errNoRetentionCutoff
+	}
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultSweepBatchSize
+	}
+	report := &RetentionReport{DryRun: opts.DryRun}
+	days, err := r.sweepDays(ctx, opts)
+	var batch []*Recording
+	for i := 0; i < len(days) && err == nil && ctx.Err() == nil; i++ {
+		if opts.MaxRecordings > 0 && report.Matched >= opts.MaxRecordings {
+			break
+		}
+		end := days[i].Add(24 * time.Hour)
+		if end.After(opts.Before) {
+			end = opts.Before
+		}
+		// Twilio's next page URIs pick up after the last recording listed, so
+		// deleting the recordings in a batch doesn't change the pages after it.
+		iter := r.GetRecordingsInRange(days[i], end, opts.Filters)
+		for ctx.Err() == nil {
+			if opts.MaxRecordings > 0 && report.Matched >= opts.MaxRecordings {
+				break
+			}
+			var page *RecordingPage
+			page, err = iter.Next(ctx)
+			if err == NoMoreResults {
+				err = nil
+				break
+			}
+			if err != nil {
+				break
+			}
+			recs := page.Recordings
+			if opts.MaxRecordings > 0 && report.Matched+len(recs) > opts.MaxRecordings {
+				recs = recs[:opts.MaxRecordings-report.Matched]
+			}
+			report.Matched += len(recs)
+			batch = append(batch, recs...)
+			if len(batch) >= batchSize {
+				r.sweepBatch(ctx, batch, opts, report)
+				batch = nil
+			}
+		}
+	}
+	r.sweepBatch(ctx, batch, opts, report)
+	if ctx.Err() != nil {
+		return report, ctx.Err()
+	}
+	return report, err
+}
+
+// sweepDays returns the UTC days, oldest first, on which recordings matching
+// opts were created.
+func (r *RecordingService) sweepDays(ctx context.Context, opts *RetentionOptions) ([]time.Time, error) {
+	seen := make(map[time.Time]bool)
+	var days []time.Time
+	iter := r.GetRecordingsInRange(Epoch, opts.Before, opts.Filters)
+	for {
+		page, err := iter.Next(ctx)
+		if err == NoMoreResults {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range page.Recordings {
+			t := rec.DateCreated.Time.UTC()
+			day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+			if !seen[day] {
+				seen[day] = true
+				days = append(days, day)
+			}
+		}
+	}
+	sort.Sort(timesAsc(days))
+	return days, nil
+}
+
+type timesAsc []time.Time
+
+func (t timesAsc) Len() int           { return len(t) }
+func (t timesAsc) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
+func (t timesAsc) Less(i, j int) bool { return t[i].Before(t[j]) }
+
+type recordingsByDate []*Recording
+
+func (r recordingsByDate) Len() int      { return len(r) }
+func (r recordingsByDate) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r recordingsByDate) Less(i, j int) bool {
+	return r[i].DateCreated.Time.Before(r[j].DateCreated.Time)
+}
+
+// sweepBatch processes recs, oldest first.
+func (r *RecordingService) sweepBatch(ctx context.Context, recs []*Recording, opts *RetentionOptions, report *RetentionReport) {
+	// Pages are newest first; reverse them before sorting so recordings
+	// created in the same second keep Twilio's order, oldest first.
+	for i, j := 0, len(recs)-1; i < j; i, j = i+1, j-1 {
+		recs[i], recs[j] = recs[j], recs[i]
+	}
+	sort.Stable(recordingsByDate(recs))
+	if opts.DryRun {
+		for _, rec := range recs {
+			report.Deleted = append(report.Deleted, rec.Sid)
+		}
+		return
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultSweepConcurrency
+	}
+	var mu sync.Mutex
+	ch := make(chan *Recording)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(recs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for rec := range ch {
+				r.sweep(ctx, rec, opts, report, &mu)
+			}
+		}()
+	}
+	for i := 0; i < len(recs) && ctx.Err() == nil; i++ {
+		select {
+		case ch <- recs[i]:
+		case <-ctx.Done():
+		}
+	}
+	close(ch)
+	wg.Wait()
+}
+
+// sweep archives and deletes a single recording, recording the outcome in
+// report, which is guarded by mu.
+func (r *RecordingService) sweep(ctx context.Context, rec *Recording, opts *RetentionOptions, report *RetentionReport, mu *sync.Mutex) {
+	fail := func(f *RetentionFailure) {
+		mu.Lock()
+		report.Failed = append(report.Failed, f)
+		mu.Unlock()
+	}
+	page, err := r.GetTranscriptions(ctx, rec.Sid, nil)
+	if err != nil {
+		fail(&RetentionFailure{RecordingSid: rec.Sid, Err: err})
+		return
+	}
+	if opts.Archive != nil {
+		if err := r.archive(ctx, rec, page.Transcriptions, opts); err != nil {
+			fail(&RetentionFailure{RecordingSid: rec.Sid, Err: err})
+			return
+		}
+		mu.Lock()
+		report.Archived++
+		mu.Unlock()
+	}
+	ok := true
+	for _, t := range page.Transcriptions {
+		if err := r.client.Transcriptions.Delete(ctx, t.Sid); err != nil {
+			fail(&RetentionFailure{RecordingSid: rec.Sid, TranscriptionSid: t.Sid, Err: err})
+			ok = false
+			continue
+		}
+		mu.Lock()
+		report.TranscriptionsDeleted++
+		mu.Unlock()
+	}
+	if !ok {
+		return
+	}
+	if err := r.Delete(ctx, rec.Sid); err != nil {
+		fail(&RetentionFailure{RecordingSid: rec.Sid, Err: err})
+		return
+	}
+	mu.Lock()
+	report.Deleted = append(report.Deleted, rec.Sid)
+	mu.Unlock()
+}
+
+func (r *RecordingService) archive(ctx context.Context, rec *Recording, transcriptions []*Transcription, opts *RetentionOptions) error {
+	format := opts.Format
+	if format == "" {
+		format = "wav"
+	}
+	audio, err := r.Download(ctx, rec.Sid, format)
+	if err != nil {
+		return err
+	}
+	defer audio.Close()
+	return opts.Archive(ctx, rec, transcriptions, audio)
+}
@@ -0,0 +1,176 @@
+package twilio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// retentionServer lists the recordings in pages, one transcription per
+// recording, and records DELETE requests. Deleting a transcription in
+// failTranscriptions returns an error. If onList is set, it's called before
+// each page of recordings is written by the listings Sweep makes a day at a
+// time, after it has found the days with recordings.
+type retentionServer struct {
+	*httptest.Server
+	pages              [][]string
+	failTranscriptions map[string]bool
+	onList             func(page int)
+
+	mu      sync.Mutex
+	deleted []string
+}
+
+func newRetentionServer(pages [][]string) *retentionServer {
+	rs := &retentionServer{pages: pages, failTranscriptions: make(map[string]bool)}
+	prefix := "/2010-04-01/Accounts/AC123/"
+	rs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "DELETE" {
+			sid := strings.TrimSuffix(path[strings.Index(path, "/")+1:], ".json")
+			if rs.failTranscriptions[sid] {
+				w.WriteHeader(400)
+				w.Write([]byte(`{"code": 20001, "message": "Transcription is in use", "status": 400}`))
+				return
+			}
+			rs.mu.Lock()
+			rs.deleted = append(rs.deleted, sid)
+			rs.mu.Unlock()
+			w.WriteHeader(204)
+			return
+		}
+		if strings.HasSuffix(path, "/Transcriptions.json") {
+			recSid := strings.Split(path, "/")[1]
+			fmt.Fprintf(w, `{"transcriptions": [{"sid": "TR%s", "recording_sid": %q}], "next_page_uri": null}`, recSid[2:], recSid)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("Page"))
+		if rs.onList != nil && r.URL.Query().Get("DateCreated>") != "" {
+			rs.onList(page)
+		}
+		recs := make([]string, len(rs.pages[page]))
+		date := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC).Format(TimeLayout)
+		for i, sid := range rs.pages[page] {
+			recs[i] = fmt.Sprintf(`{"sid": %q, "date_created": %q}`, sid, date)
+		}
+		next := "null"
+		if page < len(rs.pages)-1 {
+			q := r.URL.Query()
+			q.Set("Page", strconv.Itoa(page+1))
+			next = fmt.Sprintf(`"%s?%s"`, r.URL.Path, q.Encode())
+		}
+		fmt.Fprintf(w, `{"recordings": [%s], "next_page_uri": %s}`, strings.Join(recs, ","), next)
+	}))
+	return rs
+}
+
+func (rs *retentionServer) client() *Client {
+	client := NewClient("AC123", "456", nil)
+	client.Base = rs.URL
+	return client
+}
+
+func (rs *retentionServer) deletedSids() string {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return strings.Join(rs.deleted, ",")
+}
+
+var retentionCutoff = time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestSweepRequiresCutoff(t *testing.T) {
+	t.Parallel()
+	client := NewClient("AC123", "456", nil)
+	for _, opts := range []*RetentionOptions{nil, {DryRun: true}} {
+		if _, err := client.Recordings.Sweep(context.Background(), opts); err != errNoRetentionCutoff {
+			t.Errorf("expected errNoRetentionCutoff, got %v", err)
+		}
+	}
+}
+
+func TestSweepKeepsRecordingIfTranscriptionFails(t *testing.T) {
+	t.Parallel()
+	s := newRetentionServer([][]string{{"RE2", "RE1"}})
+	defer s.Close()
+	s.failTranscriptions["TR1"] = true
+	opts := &RetentionOptions{Before: retentionCutoff, Concurrency: 1}
+	report, err := s.client().Recordings.Sweep(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.deletedSids(); got != "TR2,RE2" {
+		t.Errorf("expected only RE2 and its transcription to be deleted, got %s", got)
+	}
+	if len(report.Failed) != 1 || report.Failed[0].RecordingSid != "RE1" || report.Failed[0].TranscriptionSid != "TR1" {
+		t.Errorf("expected a failure for TR1, got %v", report.Failed)
+	}
+	if report.Matched != 2 || report.TranscriptionsDeleted != 1 || strings.Join(report.Deleted, ",") != "RE2" {
+		t.Errorf("bad report: %#v", report)
+	}
+}
+
+func TestSweepBatches(t *testing.T) {
+	t.Parallel()
+	s := newRetentionServer([][]string{{"RE6", "RE5"}, {"RE4", "RE3"}, {"RE2", "RE1"}})
+	defer s.Close()
+	var mu sync.Mutex
+	var deletedBeforeList []string
+	s.onList = func(page int) {
+		mu.Lock()
+		deletedBeforeList = append(deletedBeforeList, s.deletedSids())
+		mu.Unlock()
+	}
+	opts := &RetentionOptions{Before: retentionCutoff, DryRun: true, BatchSize: 2, MaxRecordings: 3}
+	report, err := s.client().Recordings.Sweep(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Matched != 3 || strings.Join(report.Deleted, ",") != "RE5,RE6,RE4" {
+		t.Errorf("expected a dry run to list 3 recordings, got %#v", report)
+	}
+
+	opts.DryRun = false
+	opts.Concurrency = 1
+	deletedBeforeList = nil
+	if _, err := s.client().Recordings.Sweep(context.Background(), opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.deletedSids(); got != "TR5,RE5,TR6,RE6,TR4,RE4" {
+		t.Errorf("expected the first 3 recordings listed to be deleted, got %s", got)
+	}
+	if len(deletedBeforeList) != 2 || deletedBeforeList[1] != "TR5,RE5,TR6,RE6" {
+		t.Errorf("expected the first batch to be deleted before the second page was listed, got %q", deletedBeforeList)
+	}
+}
+
+func TestSweepCanceled(t *testing.T) {
+	t.Parallel()
+	s := newRetentionServer([][]string{{"RE2"}, {"RE1"}})
+	defer s.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.onList = func(page int) {
+		if page == 1 {
+			cancel()
+		}
+	}
+	opts := &RetentionOptions{Before: retentionCutoff, BatchSize: 1}
+	report, err := s.client().Recordings.Sweep(ctx, opts)
+	if err != context.Canceled {
+		t.Errorf("expected Canceled, got %v", err)
+	}
+	if got := s.deletedSids(); got != "TR2,RE2" {
+		t.Errorf("expected only the first batch to be deleted, got %s", got)
+	}
+	if report == nil || strings.Join(report.Deleted, ",") != "RE2" {
+		t.Errorf("expected the report to include the first batch, got %#v", report)
+	}
+}
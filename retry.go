@@ -0,0 +1,169 @@
+package twilio
+
+import (
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// A RetryPolicy controls how a Client retries requests that fail for reasons
+// that are likely to be temporary - connection errors, 429 Too Many Requests
+// responses and 5xx server errors.
+//
+// GET and DELETE requests are safe to repeat, and are retried on any of those
+// failures. Other requests (for example a POST that creates a Message or
+// a Call) are only retried if we know the request never left the machine,
+// for example if DNS resolution failed or we couldn't open a connection.
+// Otherwise we might send the same message twice.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times to try a request, including
+	// the first attempt. Values less than 2 disable retries.
+	MaxAttempts int
+	// BaseBackoff is the time to wait before the first retry. The wait
+	// doubles after every subsequent attempt.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the time to wait between attempts. A Retry-After
+	// header sent by the server is respected even if it exceeds MaxBackoff.
+	MaxBackoff time.Duration
+	// Jitter is the fraction (between 0 and 1) of each backoff that is
+	// randomized, so clients that failed at the same time don't retry at the
+	// same time.
+	Jitter float64
+}
+
+// DefaultRetryPolicy is a reasonable RetryPolicy for most applications. It
+// makes at most three attempts, waiting roughly 250ms and then 500ms between
+// them.
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxAttempts: 3,
+	BaseBackoff: 250 * time.Millisecond,
+	MaxBackoff:  5 * time.Second,
+	Jitter:      0.2,
+}
+
+// SetRetryPolicy configures c, and the Monitor and Pricing clients attached to
+// it, to retry failed requests according to the given policy. Pass nil to
+// disable retries.
+func (c *Client) SetRetryPolicy(policy *RetryPolicy) {
+	c.RetryPolicy = policy
+	if c.Monitor != nil {
+		c.Monitor.RetryPolicy = policy
+	}
+	if c.Pricing != nil {
+		c.Pricing.RetryPolicy = policy
+	}
+}
+
+// backoff returns the amount of time to wait after the given (1-indexed)
+// attempt failed.
+func (r *RetryPolicy) backoff(attempt int) time.Duration {
+	wait := r.BaseBackoff
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if r.MaxBackoff > 0 && wait >= r.MaxBackoff {
+			break
+		}
+	}
+	if r.MaxBackoff > 0 && wait > r.MaxBackoff {
+		wait = r.MaxBackoff
+	}
+	if r.Jitter > 0 && wait > 0 {
+		jitter := r.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		// take up to jitter*wait off of the wait, so we never exceed
+		// MaxBackoff.
+		wait -= time.Duration(rand.Float64() * jitter * float64(wait))
+	}
+	return wait
+}
+
+// idempotent returns true if a request with the given method can be safely
+// repeated.
+func idempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "DELETE":
+		return true
+	default:
+		return false
+	}
+}
+
+// shouldRetry returns true if a request with the given method that failed with
+// err can be retried. resp is nil if we never got a response from the server.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if resp != nil {
+		if !idempotent(method) {
+			// The server saw the request; we can't know whether it acted on
+			// it.
+			return false
+		}
+		return resp.StatusCode == 429 || resp.StatusCode >= 500
+	}
+	if isPreSendError(err) {
+		return true
+	}
+	return idempotent(method)
+}
+
+// isPreSendError returns true if err indicates that the request was never
+// written to the network, so the server can't have acted on it.
+func isPreSendError(err error) bool {
+	if uerr, ok := err.(*url.Error); ok {
+		err = uerr.Err
+	}
+	switch e := err.(type) {
+	case *net.DNSError:
+		return true
+	case *net.OpError:
+		return e.Op == "dial"
+	default:
+		return false
+	}
+}
+
+// retryAfter returns the duration the server asked us to wait in the
+// Retry-After header, or 0 if the header is absent or invalid.
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleep waits for the given duration, returning early (with false) if ctx is
+// canceled first, or if waiting would run past the context's deadline.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(d).After(deadline) {
+		return false
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,181 @@
+package twilio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+var fastRetryPolicy = &RetryPolicy{
+	MaxAttempts: 3,
+	BaseBackoff: time.Millisecond,
+	MaxBackoff:  5 * time.Millisecond,
+}
+
+func TestRetryGetOnServerError(t *testing.T) {
+	t.Parallel()
+	var count int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if atomic.AddInt32(&count, 1) < 3 {
+			w.WriteHeader(503)
+			w.Write([]byte(`{"code": 20503, "message": "Service unavailable", "status": 503}`))
+			return
+		}
+		w.Write(makeCallResponse)
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetRetryPolicy(fastRetryPolicy)
+	call, err := client.Calls.Get(context.Background(), "CA47b862ce3b99a6d79939320a9aa54a02")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Status != StatusQueued {
+		t.Errorf("expected Status to be queued, got %s", call.Status)
+	}
+	if c := atomic.LoadInt32(&count); c != 3 {
+		t.Errorf("expected 3 requests, got %d", c)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	t.Parallel()
+	client, s := getServerCode([]byte(`{"code": 20429, "message": "Too many requests", "status": 429}`), 429)
+	defer s.Close()
+	client.SetRetryPolicy(fastRetryPolicy)
+	_, err := client.Calls.Get(context.Background(), "CA123")
+	if err == nil {
+		t.Fatal("expected non-nil error, got nil")
+	}
+	if l := len(s.URLs); l != 3 {
+		t.Errorf("expected 3 requests, got %d", l)
+	}
+}
+
+func TestNoRetryWithoutPolicy(t *testing.T) {
+	t.Parallel()
+	client, s := getServerCode([]byte(`{"code": 20500, "message": "Internal error", "status": 500}`), 500)
+	defer s.Close()
+	_, err := client.Calls.Get(context.Background(), "CA123")
+	if err == nil {
+		t.Fatal("expected non-nil error, got nil")
+	}
+	if l := len(s.URLs); l != 1 {
+		t.Errorf("expected 1 request, got %d", l)
+	}
+}
+
+func TestNoRetryPostAfterSend(t *testing.T) {
+	t.Parallel()
+	client, s := getServerCode([]byte(`{"code": 20500, "message": "Internal error", "status": 500}`), 500)
+	defer s.Close()
+	client.SetRetryPolicy(fastRetryPolicy)
+	_, err := client.Messages.Create(context.Background(), url.Values{"To": []string{to}})
+	if err == nil {
+		t.Fatal("expected non-nil error, got nil")
+	}
+	if l := len(s.URLs); l != 1 {
+		t.Errorf("expected POST to be attempted once, got %d attempts", l)
+	}
+}
+
+func TestRetryPostBeforeSend(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	// grab an address that nothing is listening on.
+	base := s.URL
+	s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = base
+	client.SetRetryPolicy(fastRetryPolicy)
+	count := 0
+	client.Client.Client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		count++
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	_, err := client.Messages.Create(context.Background(), url.Values{"To": []string{to}})
+	if err == nil {
+		t.Fatal("expected non-nil error, got nil")
+	}
+	if count != 3 {
+		t.Errorf("expected a POST that couldn't connect to be attempted 3 times, got %d", count)
+	}
+}
+
+func TestRetryRespectsDeadline(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(429)
+		w.Write([]byte(`{"code": 20429, "message": "Too many requests", "status": 429}`))
+	}))
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	client.SetRetryPolicy(fastRetryPolicy)
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Calls.Get(ctx, "CA123")
+	if err == nil {
+		t.Fatal("expected non-nil error, got nil")
+	}
+	if since := time.Since(start); since > 400*time.Millisecond {
+		t.Errorf("expected to give up right away, waited %v", since)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2016, 11, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"-1", 0},
+		{"garbage", 0},
+		{now.Add(2 * time.Minute).Format(http.TimeFormat), 2 * time.Minute},
+		{now.Add(-2 * time.Minute).Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		if tt.header != "" {
+			resp.Header.Set("Retry-After", tt.header)
+		}
+		if got := retryAfter(resp, now); got != tt.want {
+			t.Errorf("retryAfter(%q): got %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+	r := &RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, w := range want {
+		if got := r.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d): got %v, want %v", i+1, got, w)
+		}
+	}
+	r.Jitter = 0.5
+	for i := 1; i < 10; i++ {
+		if got := r.backoff(i); got > time.Second || got < 50*time.Millisecond {
+			t.Errorf("backoff(%d) with jitter: got out of range value %v", i, got)
+		}
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,209 @@
+package twilio
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"unicode/utf16"
+)
+
+// An Encoding is the character set used to send an SMS.
+type Encoding string
+
+const (
+	// EncodingGSM7 packs each character into 7 bits, so a single SMS holds 160
+	// characters. Characters in the GSM extended table take up two.
+	EncodingGSM7 Encoding = "GSM-7"
+	// EncodingUCS2 is used if any character isn't in the GSM-7 alphabet. Each
+	// character takes 16 bits (or 32, outside the Basic Multilingual Plane),
+	// so a single SMS holds 70.
+	EncodingUCS2 Encoding = "UCS-2"
+)
+
+// The number of units (septets for GSM-7, UTF-16 code units for UCS-2) that
+// fit in a single SMS, and in each part of a concatenated SMS, which loses
+// some space to the User Data Header.
+const (
+	gsm7SingleSegment = 160
+	gsm7MultiSegment  = 153
+	ucs2SingleSegment = 70
+	ucs2MultiSegment  = 67
+)
+
+// The GSM 03.38 basic character set, minus the escape character.
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// Characters in the GSM 03.38 extension table, which are sent as an escape
+// character followed by the character, so they take up two septets.
+const gsm7Extended = "\f^{}\\[~]|€"
+
+// gsm7Septets returns the number of septets r takes in GSM-7, or 0 if r
+// isn't in the GSM-7 alphabet.
+func gsm7Septets(r rune) int {
+	switch {
+	case strings.ContainsRune(gsm7Basic, r):
+		return 1
+	case strings.ContainsRune(gsm7Extended, r):
+		return 2
+	default:
+		return 0
+	}
+}
+
+// SegmentInfo describes how a message body will be sent over SMS.
+type SegmentInfo struct {
+	Encoding Encoding
+	// Segments is the number of SMS messages the body will be split into;
+	// Twilio charges for each one. It's 0 for an empty body.
+	Segments int
+	// Units is the length of the body in the Encoding: septets for GSM-7,
+	// where extended characters count twice, or UTF-16 code units for UCS-2.
+	Units int
+	// PerSegment is the most Units each segment can hold.
+	PerSegment int
+	// NonGSM lists the characters that forced the body to be sent as UCS-2,
+	// in the order they first appear. It's empty for GSM-7 bodies.
+	NonGSM []rune
+}
+
+// CountSegments returns the encoding and the number of segments needed to
+// send body as an SMS. Call it before MessageService.Create to see how many
+// messages you'll be charged for; use ReplaceNonGSM to try to fit the body
+// into fewer segments.
+//
+// The count may be wrong for bodies that Twilio changes before sending, for
+// example with the SmartEncoded parameter, or for MMS.
+func CountSegments(body string) *SegmentInfo {
+	info := &SegmentInfo{Encoding: EncodingGSM7}
+	// the cost of each character, in the body's encoding
+	var costs []int
+	seen := make(map[rune]bool)
+	for _, r := range body {
+		n := gsm7Septets(r)
+		if n == 0 && !seen[r] {
+			seen[r] = true
+			info.NonGSM = append(info.NonGSM, r)
+		}
+		costs = append(costs, n)
+	}
+	single, multi := gsm7SingleSegment, gsm7MultiSegment
+	if len(info.NonGSM) > 0 {
+		info.Encoding = EncodingUCS2
+		single, multi = ucs2SingleSegment, ucs2MultiSegment
+		costs = costs[:0]
+		for _, r := range body {
+			costs = append(costs, len(utf16.Encode([]rune{r})))
+		}
+	}
+	for _, c := range costs {
+		info.Units += c
+	}
+	switch {
+	case info.Units == 0:
+		info.PerSegment = single
+	case info.Units <= single:
+		info.Segments = 1
+		info.PerSegment = single
+	default:
+		// Escape sequences and surrogate pairs can't be split across
+		// segments, so a segment may end up with fewer units than it holds.
+		info.PerSegment = multi
+		info.Segments = 1
+		used := 0
+		for _, c := range costs {
+			if used+c > multi {
+				info.Segments++
+				used = 0
+			}
+			used += c
+		}
+	}
+	return info
+}
+
+// GSMReplacements are the suggested replacements for common characters that
+// aren't in the GSM-7 alphabet, like the "smart" quotes inserted by word
+// processors and phone keyboards, and a few emoji. ReplaceNonGSM uses them.
+var GSMReplacements = map[rune]string{
+	'‘':      "'",
+	'’':      "'",
+	'‚':      "'",
+	'′':      "'",
+	'“':      "\"",
+	'”':      "\"",
+	'„':      "\"",
+	'″':      "\"",
+	'–':      "-",
+	'—':      "-",
+	'‐':      "-",
+	'−':      "-",
+	'…':      "...",
+	'•':      "*",
+	'\u00a0': " ",
+	'\u2009': " ",
+	'\u200b': "",
+	'\t':     " ",
+	'´':      "'",
+	'`':      "'",
+	'ç':      "Ç",
+	'🙂':      ":)",
+	'😊':      ":)",
+	'😀':      ":D",
+	'😃':      ":D",
+	'😉':      ";)",
+	'🙁':      ":(",
+	'😢':      ":'(",
+	'😛':      ":P",
+	'❤':      "<3",
+	'👍':      "(y)",
+}
+
+// ReplaceNonGSM returns body with the characters in GSMReplacements replaced,
+// so it can be sent as GSM-7, and the characters that still aren't in the
+// GSM-7 alphabet. If remaining is empty, the result will be sent as GSM-7.
+func ReplaceNonGSM(body string) (replaced string, remaining []rune) {
+	seen := make(map[rune]bool)
+	buf := make([]byte, 0, len(body))
+	for _, r := range body {
+		if gsm7Septets(r) > 0 {
+			buf = append(buf, string(r)...)
+			continue
+		}
+		if s, ok := GSMReplacements[r]; ok {
+			buf = append(buf, s...)
+			continue
+		}
+		buf = append(buf, string(r)...)
+		if !seen[r] {
+			seen[r] = true
+			remaining = append(remaining, r)
+		}
+	}
+	return string(buf), remaining
+}
+
+var errNoOutboundPrice = errors.New("twilio: No outbound SMS price found")
+
+// EstimateCost returns the cost of sending the segments at the given price,
+// in price.PriceUnit. Prices vary by carrier, so EstimateCost uses the
+// highest current outbound price in price; get one with
+// client.Pricing.Messaging.Countries.Get.
+func (s *SegmentInfo) EstimateCost(price *MessagePrice) (*big.Rat, error) {
+	var max *big.Rat
+	for _, outbound := range price.OutboundSMSPrices {
+		for _, p := range outbound.Prices {
+			r, ok := new(big.Rat).SetString(p.CurrentPrice)
+			if !ok {
+				return nil, errors.New("twilio: Invalid price: " + p.CurrentPrice)
+			}
+			if max == nil || r.Cmp(max) > 0 {
+				max = r
+			}
+		}
+	}
+	if max == nil {
+		return nil, errNoOutboundPrice
+	}
+	return max.Mul(max, big.NewRat(int64(s.Segments), 1)), nil
+}
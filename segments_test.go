@@ -0,0 +1,90 @@
+package twilio
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+var segmentTests = []struct {
+	body     string
+	encoding Encoding
+	segments int
+	units    int
+}{
+	{"", EncodingGSM7, 0, 0},
+	{"hello", EncodingGSM7, 1, 5},
+	{strings.Repeat("a", 160), EncodingGSM7, 1, 160},
+	{strings.Repeat("a", 161), EncodingGSM7, 2, 161},
+	{strings.Repeat("a", 306), EncodingGSM7, 2, 306},
+	{strings.Repeat("a", 307), EncodingGSM7, 3, 307},
+	{"price: 5€ {ok}", EncodingGSM7, 1, 17},
+	// the escape sequence for € can't be split across segments
+	{strings.Repeat("a", 152) + "€" + strings.Repeat("a", 152), EncodingGSM7, 3, 306},
+	{"héllo ☃", EncodingUCS2, 1, 7},
+	{strings.Repeat("☃", 70), EncodingUCS2, 1, 70},
+	{strings.Repeat("☃", 71), EncodingUCS2, 2, 71},
+	{strings.Repeat("😀", 35), EncodingUCS2, 1, 70},
+	// surrogate pairs can't be split either, so only 33 fit in a segment
+	{strings.Repeat("😀", 67), EncodingUCS2, 3, 134},
+}
+
+func TestCountSegments(t *testing.T) {
+	t.Parallel()
+	for _, tt := range segmentTests {
+		info := CountSegments(tt.body)
+		if info.Encoding != tt.encoding || info.Segments != tt.segments || info.Units != tt.units {
+			t.Errorf("CountSegments(%q): got %s/%d segments/%d units, want %s/%d/%d", tt.body, info.Encoding, info.Segments, info.Units, tt.encoding, tt.segments, tt.units)
+		}
+	}
+}
+
+func TestCountSegmentsNonGSM(t *testing.T) {
+	t.Parallel()
+	info := CountSegments("It’s a ☃, it’s a ☃!")
+	if len(info.NonGSM) != 2 || info.NonGSM[0] != '’' || info.NonGSM[1] != '☃' {
+		t.Errorf("expected ’ and ☃ to force UCS-2, got %q", string(info.NonGSM))
+	}
+	if info.PerSegment != 70 {
+		t.Errorf("expected 70 characters per segment, got %d", info.PerSegment)
+	}
+}
+
+func TestReplaceNonGSM(t *testing.T) {
+	t.Parallel()
+	out, remaining := ReplaceNonGSM("“Hi” — it’s me… 🙂")
+	if want := `"Hi" - it's me... :)`; out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("expected no remaining characters, got %q", string(remaining))
+	}
+	if info := CountSegments(out); info.Encoding != EncodingGSM7 {
+		t.Errorf("expected replaced body to be GSM-7, got %s", info.Encoding)
+	}
+	out, remaining = ReplaceNonGSM("“snow” ☃")
+	if out != `"snow" ☃` || len(remaining) != 1 || remaining[0] != '☃' {
+		t.Errorf("expected ☃ to remain, got %q %q", out, string(remaining))
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	t.Parallel()
+	price := &MessagePrice{
+		OutboundSMSPrices: []OutboundSMSPrice{
+			{Carrier: "A", Prices: []InboundPrice{{CurrentPrice: "0.0075"}}},
+			{Carrier: "B", Prices: []InboundPrice{{CurrentPrice: "0.01"}}},
+		},
+		PriceUnit: "USD",
+	}
+	cost, err := CountSegments(strings.Repeat("a", 400)).EstimateCost(price)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := big.NewRat(3, 100); cost.Cmp(want) != 0 {
+		t.Errorf("expected cost of %s, got %s", want.FloatString(4), cost.FloatString(4))
+	}
+	if _, err := CountSegments("hi").EstimateCost(&MessagePrice{}); err == nil {
+		t.Error("expected an error with no prices")
+	}
+}
@@ -1,7 +1,9 @@
 package twilio
 
 import (
+	"fmt"
 	"net/url"
+	"time"
 
 	"golang.org/x/net/context"
 )
@@ -65,6 +67,31 @@ func (c *TranscriptionService) GetPage(ctx context.Context, data url.Values) (*T
 
 type TranscriptionPageIterator struct {
 	p *PageIterator
+	// start and end are the bounds of a GetTranscriptionsInRange iterator, or
+	// the zero time otherwise.
+	start time.Time
+	end   time.Time
+}
+
+// Prefetch configures the iterator to fetch up to depth pages in the
+// background. See PageIterator.Prefetch.
+func (c *TranscriptionPageIterator) Prefetch(depth int) {
+	c.p.Prefetch(depth)
+}
+
+// Stop cancels any prefetch requests in flight. Subsequent calls to Next
+// return NoMoreResults.
+func (c *TranscriptionPageIterator) Stop() {
+	c.p.Stop()
+}
+
+// Cursor returns the position of the iterator. Pass it to ResumePageIterator
+// to continue from the same place.
+func (c *TranscriptionPageIterator) Cursor() Cursor {
+	cur := c.p.Cursor()
+	cur.Start = c.start
+	cur.End = c.end
+	return cur
 }
 
 // GetPageIterator returns a TranscriptionPageIterator with the given page
@@ -77,9 +104,29 @@ func (c *TranscriptionService) GetPageIterator(data url.Values) *TranscriptionPa
 	}
 }
 
+// ResumePageIterator returns an iterator that continues from cur, the
+// Cursor of an earlier iterator.
+func (c *TranscriptionService) ResumePageIterator(cur Cursor) *TranscriptionPageIterator {
+	iter := ResumePageIterator(c.client, transcriptionPathPart, cur)
+	return &TranscriptionPageIterator{
+		p:     iter,
+		start: cur.Start,
+		end:   cur.End,
+	}
+}
+
 // Next returns the next page of resources. If there are no more resources,
-// NoMoreResults is returned.
+// NoMoreResults is returned. If the iterator was returned by
+// GetTranscriptionsInRange, Next may fetch several pages to find one with
+// results in the range.
 func (c *TranscriptionPageIterator) Next(ctx context.Context) (*TranscriptionPage, error) {
+	if !c.end.IsZero() {
+		page, err := nextPageInRange(ctx, c.p, c.start, c.end, false, func() datedPage { return new(TranscriptionPage) })
+		if err != nil {
+			return nil, err
+		}
+		return page.(*TranscriptionPage), nil
+	}
 	cp := new(TranscriptionPage)
 	err := c.p.Next(ctx, cp)
 	if err != nil {
@@ -88,3 +135,90 @@ func (c *TranscriptionPageIterator) Next(ctx context.Context) (*TranscriptionPag
 	c.p.SetNextPageURI(cp.NextPageURI)
 	return cp, nil
 }
+
+// GetTranscriptionsInRange returns an iterator over the transcriptions created
+// in the range [start, end), optionally further filtered by data. start and end
+// may have any precision; transcriptions outside the range are removed from
+// each page. GetTranscriptionsInRange panics if start is after end. If you have
+// an end, but don't want to specify a start, use twilio.Epoch for start. If you
+// have a start, but don't want to specify an end, use twilio.HeatDeath for end.
+// The API can't filter transcriptions by date, and doesn't document the order
+// they're returned in, so this pages through every transcription, filtering as
+// it goes.
+// Returned pages have at most PageSize results, but may have fewer.
+func (c *TranscriptionService) GetTranscriptionsInRange(start time.Time, end time.Time, data url.Values) *TranscriptionPageIterator {
+	if start.After(end) {
+		panic("start date is after end date")
+	}
+	d := url.Values{}
+	for k, v := range data {
+		d[k] = v
+	}
+	d.Del("Page") // just in case
+	return &TranscriptionPageIterator{
+		p:     NewPageIterator(c.client, d, transcriptionPathPart),
+		start: start,
+		end:   end,
+	}
+}
+
+func (p *TranscriptionPage) dates() ([]time.Time, error) {
+	times := make([]time.Time, len(p.Transcriptions))
+	for i, transcription := range p.Transcriptions {
+		if !transcription.DateCreated.Valid {
+			return nil, fmt.Errorf("Couldn't verify the date of transcription: %#v", transcription)
+		}
+		times[i] = transcription.DateCreated.Time
+	}
+	return times, nil
+}
+
+func (p *TranscriptionPage) removeIndexes(indexes []int) {
+	// reverse order so we don't delete the wrong index
+	for i := len(indexes) - 1; i >= 0; i-- {
+		p.Transcriptions = append(p.Transcriptions[:indexes[i]], p.Transcriptions[indexes[i]+1:]...)
+	}
+}
+
+// TranscriptionIterator yields Transcriptions one at a time. Call Next to
+// advance the iterator, and Value to get the current Transcription.
+type TranscriptionIterator struct {
+	*ItemIterator
+}
+
+// NewTranscriptionIterator returns a TranscriptionIterator that yields each
+// Transcription in the pages returned by p.
+func NewTranscriptionIterator(p *TranscriptionPageIterator) *TranscriptionIterator {
+	return &TranscriptionIterator{newItemIterator(p, func(ctx context.Context) ([]interface{}, error) {
+		page, err := p.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(page.Transcriptions))
+		for i := range page.Transcriptions {
+			items[i] = page.Transcriptions[i]
+		}
+		return items, nil
+	})}
+}
+
+// Value returns the current Transcription, or nil if Next hasn't been called or
+// returned false.
+func (t *TranscriptionIterator) Value() *Transcription {
+	v, _ := t.ItemIterator.Value().(*Transcription)
+	return v
+}
+
+// GetIterator returns an iterator over the Transcriptions matching the filters
+// in data, fetching new pages as needed.
+func (c *TranscriptionService) GetIterator(data url.Values) *TranscriptionIterator {
+	return NewTranscriptionIterator(c.GetPageIterator(data))
+}
+
+// ResumeIterator returns an iterator that continues from cur, the Cursor of
+// an earlier iterator, skipping any resources that were already returned.
+func (c *TranscriptionService) ResumeIterator(cur Cursor) *TranscriptionIterator {
+	iter := NewTranscriptionIterator(c.ResumePageIterator(cur))
+	iter.resume(cur)
+	return iter
+}
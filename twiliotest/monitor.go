@@ -0,0 +1,228 @@
+package twiliotest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	twilio "github.com/saintpete/twilio-go"
+)
+
+// AddAlert adds an Alert with the given error code, log level and alert text
+// to the main account, and returns the Alert's sid. alertText should be
+// formatted like a query string, for example "ErrorCode=11200&Msg=...".
+func (s *Server) AddAlert(code twilio.Code, level twilio.LogLevel, alertText string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	it := s.newItem("NO")
+	for k, v := range map[string]interface{}{
+		"account_sid":    s.AccountSid,
+		"alert_text":     alertText,
+		"api_version":    twilio.APIVersion,
+		"date_generated": it.created,
+		"error_code":     strconv.Itoa(int(code)),
+		"log_level":      level,
+		"more_info":      code.URL(),
+		"request_method": "POST",
+		"request_url":    "",
+		"resource_sid":   "",
+		"service_sid":    nil,
+		"url":            s.URL + "/v1/Alerts/" + it.sid,
+	} {
+		it.fields[k] = v
+	}
+	s.alerts.add(it)
+	return it.sid
+}
+
+// parseAlertDate parses a StartDate or EndDate filter, which may be a day or
+// a RFC 3339 timestamp.
+func parseAlertDate(val string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		return t, nil
+	}
+	return time.Parse(twilio.APISearchLayout, val)
+}
+
+func (s *Server) serveAlerts(w http.ResponseWriter, r *http.Request, authed *account) {
+	sid := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/v1/Alerts"), "/")
+	if sid != "" {
+		it, ok := s.alerts.items[sid]
+		if !ok || it.fields["account_sid"] != authed.sid {
+			s.notFound(w, r)
+			return
+		}
+		switch r.Method {
+		case "GET":
+			writeJSON(w, http.StatusOK, render(it, time.RFC3339))
+		case "DELETE":
+			s.alerts.remove(sid)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			s.methodNotAllowed(w, r)
+		}
+		return
+	}
+	if r.Method != "GET" {
+		s.methodNotAllowed(w, r)
+		return
+	}
+	var start, end time.Time
+	for _, f := range []struct {
+		param string
+		t     *time.Time
+	}{{"StartDate", &start}, {"EndDate", &end}} {
+		val := r.Form.Get(f.param)
+		if val == "" {
+			continue
+		}
+		t, err := parseAlertDate(val)
+		if err != nil {
+			s.writeError(w, http.StatusBadRequest, twilio.CodeUnknownParameters, "Invalid "+f.param+": "+val)
+			return
+		}
+		*f.t = t
+	}
+	var alerts []map[string]interface{}
+	for _, it := range s.alerts.newest() {
+		if it.fields["account_sid"] != authed.sid {
+			continue
+		}
+		if level := r.Form.Get("LogLevel"); level != "" && string(it.fields["log_level"].(twilio.LogLevel)) != level {
+			continue
+		}
+		if !start.IsZero() && it.created.Before(start) {
+			continue
+		}
+		if !end.IsZero() && it.created.After(end) {
+			continue
+		}
+		alerts = append(alerts, render(it, time.RFC3339))
+	}
+	s.writeMetaPage(w, r, "alerts", alerts)
+}
+
+type countryPrices struct {
+	country      string
+	prefix       string
+	smsOutbound  string
+	smsInbound   string
+	voiceOut     string
+	voiceIn      string
+	localNumber  string
+	tollFree     string
+	mobileNumber string
+}
+
+var prices = map[string]countryPrices{
+	"US": {"United States", "+1", "0.0075", "0.0075", "0.013", "0.0085", "1.00", "2.00", ""},
+	"GB": {"United Kingdom", "+44", "0.04", "0.0075", "0.024", "0.01", "1.00", "2.00", "1.00"},
+}
+
+var priceCountries = []string{"GB", "US"}
+
+func (s *Server) servePricing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		s.methodNotAllowed(w, r)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		s.notFound(w, r)
+		return
+	}
+	product, kind := parts[0], parts[1]
+	if product != "Messaging" && product != "Voice" && product != "PhoneNumbers" {
+		s.notFound(w, r)
+		return
+	}
+	if len(parts) == 2 {
+		if kind != "Countries" {
+			s.notFound(w, r)
+			return
+		}
+		countries := make([]map[string]interface{}, len(priceCountries))
+		for i, iso := range priceCountries {
+			countries[i] = map[string]interface{}{
+				"country":     prices[iso].country,
+				"iso_country": iso,
+				"url":         s.URL + "/v1/" + product + "/Countries/" + iso,
+			}
+		}
+		s.writeMetaPage(w, r, "countries", countries)
+		return
+	}
+	if product == "Voice" && kind == "Numbers" {
+		s.serveNumberPrice(w, r, parts[2])
+		return
+	}
+	iso := strings.ToUpper(parts[2])
+	p, ok := prices[iso]
+	if kind != "Countries" || !ok {
+		s.notFound(w, r)
+		return
+	}
+	resp := map[string]interface{}{
+		"country":     p.country,
+		"iso_country": iso,
+		"price_unit":  "USD",
+		"url":         s.URL + r.URL.Path,
+	}
+	switch product {
+	case "Messaging":
+		resp["outbound_sms_prices"] = []map[string]interface{}{{
+			"carrier": "Default",
+			"mcc":     "",
+			"mnc":     "",
+			"prices":  []map[string]string{inboundPrice("mobile", p.smsOutbound)},
+		}}
+		resp["inbound_sms_prices"] = []map[string]string{inboundPrice("local", p.smsInbound)}
+	case "Voice":
+		resp["outbound_prefix_prices"] = []map[string]interface{}{{
+			"base_price":    p.voiceOut,
+			"current_price": p.voiceOut,
+			"friendly_name": "Programmable Outbound Minute - " + p.country,
+			"prefixes":      []string{strings.TrimPrefix(p.prefix, "+")},
+		}}
+		resp["inbound_call_prices"] = []map[string]string{inboundPrice("local", p.voiceIn)}
+	case "PhoneNumbers":
+		numberPrices := []map[string]string{
+			inboundPrice("local", p.localNumber),
+			inboundPrice("toll free", p.tollFree),
+		}
+		if p.mobileNumber != "" {
+			numberPrices = append(numberPrices, inboundPrice("mobile", p.mobileNumber))
+		}
+		resp["phone_number_prices"] = numberPrices
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func inboundPrice(numberType string, price string) map[string]string {
+	return map[string]string{
+		"base_price":    price,
+		"current_price": price,
+		"number_type":   numberType,
+	}
+}
+
+func (s *Server) serveNumberPrice(w http.ResponseWriter, r *http.Request, number string) {
+	for _, iso := range priceCountries {
+		p := prices[iso]
+		if !strings.HasPrefix(number, p.prefix) {
+			continue
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"country":             p.country,
+			"iso_country":         iso,
+			"number":              number,
+			"inbound_call_price":  inboundPrice("local", p.voiceIn),
+			"outbound_call_price": map[string]string{"base_price": p.voiceOut, "current_price": p.voiceOut},
+			"price_unit":          "USD",
+			"url":                 s.URL + r.URL.Path,
+		})
+		return
+	}
+	s.notFound(w, r)
+}
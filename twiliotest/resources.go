@@ -0,0 +1,792 @@
+package twiliotest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	twilio "github.com/saintpete/twilio-go"
+)
+
+// A resource describes a list resource under /2010-04-01/Accounts/AC123.
+type resource struct {
+	// key is the name of the list in a page of results.
+	key string
+	// create is nil if the resource can't be created through the API.
+	create func(s *Server, acct *account, r *http.Request) (*item, *apiError)
+	// update is nil if the resource can't be updated.
+	update func(s *Server, it *item, r *http.Request) *apiError
+	// advance, if set, is called every time an instance is fetched.
+	advance   func(s *Server, it *item)
+	deletable bool
+	// Equality filters for lists, from query parameter to field name.
+	filters map[string]string
+	// The date filter for lists, if any.
+	dateParam string
+	dateField string
+}
+
+var resources = map[string]*resource{
+	"Messages": {
+		key:       "messages",
+		create:    (*Server).createMessage,
+		update:    (*Server).updateMessage,
+		advance:   (*Server).advanceMessage,
+		deletable: true,
+		filters:   map[string]string{"To": "to", "From": "from", "Status": "status"},
+		dateParam: "DateSent",
+		dateField: "date_sent",
+	},
+	"Calls": {
+		key:       "calls",
+		create:    (*Server).createCall,
+		update:    (*Server).updateCall,
+		advance:   (*Server).advanceCall,
+		deletable: true,
+		filters:   map[string]string{"To": "to", "From": "from", "Status": "status", "ParentCallSid": "parent_call_sid"},
+		dateParam: "StartTime",
+		dateField: "start_time",
+	},
+	"IncomingPhoneNumbers": {
+		key: "incoming_phone_numbers",
+		create: func(s *Server, acct *account, r *http.Request) (*item, *apiError) {
+			return s.createNumber(acct, r, "")
+		},
+		update:    updateFields(map[string]string{"FriendlyName": "friendly_name", "VoiceUrl": "voice_url", "VoiceMethod": "voice_method", "SmsUrl": "sms_url", "SmsMethod": "sms_method", "StatusCallback": "status_callback"}),
+		deletable: true,
+		filters:   map[string]string{"PhoneNumber": "phone_number", "FriendlyName": "friendly_name"},
+	},
+	"Recordings": {
+		key:       "recordings",
+		deletable: true,
+		filters:   map[string]string{"CallSid": "call_sid"},
+		dateParam: "DateCreated",
+		dateField: "date_created",
+	},
+	"Transcriptions": {
+		key:       "transcriptions",
+		deletable: true,
+	},
+	"Queues": {
+		key:       "queues",
+		create:    (*Server).createQueue,
+		update:    (*Server).updateQueue,
+		deletable: true,
+	},
+	"Conferences": {
+		key:       "conferences",
+		update:    (*Server).updateConference,
+		filters:   map[string]string{"FriendlyName": "friendly_name", "Status": "status"},
+		dateParam: "DateCreated",
+		dateField: "date_created",
+	},
+}
+
+func (a *account) collection(name string) *collection {
+	c, ok := a.resources[name]
+	if !ok {
+		c = newCollection()
+		a.resources[name] = c
+	}
+	return c
+}
+
+// canAccess returns true if requests authenticated as authed can access
+// resources belonging to acct.
+func canAccess(authed *account, acct *account) bool {
+	return acct == authed || acct.fields["owner_account_sid"] == authed.sid
+}
+
+func uri(acct *account, parts ...string) string {
+	return "/" + strings.Join(append([]string{twilio.APIVersion, "Accounts", acct.sid}, parts...), "/") + ".json"
+}
+
+func (s *Server) serveAPI(w http.ResponseWriter, r *http.Request, authed *account, path string) {
+	parts := strings.Split(path, "/")
+	if len(parts) == 1 && parts[0] == "Accounts.json" {
+		s.serveAccounts(w, r, authed)
+		return
+	}
+	if parts[0] != "Accounts" || len(parts) < 2 {
+		s.notFound(w, r)
+		return
+	}
+	if len(parts) == 2 {
+		s.serveAccount(w, r, authed, strings.TrimSuffix(parts[1], ".json"))
+		return
+	}
+	acct, ok := s.accounts[parts[1]]
+	if !ok || !canAccess(authed, acct) {
+		s.notFound(w, r)
+		return
+	}
+	parts = parts[2:]
+	parts[len(parts)-1] = strings.TrimSuffix(parts[len(parts)-1], ".json")
+	res, ok := resources[parts[0]]
+	if !ok {
+		s.notFound(w, r)
+		return
+	}
+	coll := acct.collection(parts[0])
+	switch {
+	case len(parts) == 1:
+		s.serveList(w, r, acct, res, coll, nil)
+	case len(parts) == 2 && parts[0] == "IncomingPhoneNumbers" && (parts[1] == "Local" || parts[1] == "TollFree"):
+		s.serveNumbers(w, r, acct, res, coll, parts[1])
+	case len(parts) == 2 && parts[0] == "Recordings" && (strings.HasSuffix(parts[1], ".wav") || strings.HasSuffix(parts[1], ".mp3")):
+		s.serveRecordingAudio(w, r, coll, parts[1])
+	case len(parts) == 2:
+		s.serveInstance(w, r, res, coll, parts[1])
+	case len(parts) == 3 && parts[0] == "Calls" && parts[2] == "Recordings":
+		s.serveList(w, r, acct, resources["Recordings"], acct.collection("Recordings"), map[string]string{"call_sid": parts[1]})
+	case len(parts) == 3 && parts[0] == "Recordings" && parts[2] == "Transcriptions":
+		s.serveList(w, r, acct, resources["Transcriptions"], acct.collection("Transcriptions"), map[string]string{"recording_sid": parts[1]})
+	default:
+		s.notFound(w, r)
+	}
+}
+
+// serveList lists or creates instances of res. If parent is set, only items
+// whose fields match it are listed.
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, acct *account, res *resource, coll *collection, parent map[string]string) {
+	switch r.Method {
+	case "GET":
+		items := coll.newest()
+		for field, val := range parent {
+			var matched []*item
+			for _, it := range items {
+				if it.fields[field] == val {
+					matched = append(matched, it)
+				}
+			}
+			items = matched
+		}
+		items = filter(items, r, res.filters)
+		if res.dateParam != "" {
+			var err *apiError
+			items, err = filterDates(items, r, res.dateParam, res.dateField)
+			if err != nil {
+				s.writeAPIError(w, err)
+				return
+			}
+		}
+		s.writePage(w, r, res.key, items)
+	case "POST":
+		if res.create == nil || parent != nil {
+			s.methodNotAllowed(w, r)
+			return
+		}
+		it, err := res.create(s, acct, r)
+		if err != nil {
+			s.writeAPIError(w, err)
+			return
+		}
+		coll.add(it)
+		writeJSON(w, http.StatusCreated, render(it, twilio.TimeLayout))
+	default:
+		s.methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveInstance(w http.ResponseWriter, r *http.Request, res *resource, coll *collection, sid string) {
+	it, ok := coll.items[sid]
+	if !ok {
+		s.notFound(w, r)
+		return
+	}
+	switch r.Method {
+	case "GET":
+		if res.advance != nil {
+			res.advance(s, it)
+		}
+	case "POST":
+		if res.update == nil {
+			s.methodNotAllowed(w, r)
+			return
+		}
+		if err := res.update(s, it, r); err != nil {
+			s.writeAPIError(w, err)
+			return
+		}
+		s.touch(it)
+	case "DELETE":
+		if !res.deletable {
+			s.methodNotAllowed(w, r)
+			return
+		}
+		coll.remove(sid)
+		if number, ok := it.fields["phone_number"].(string); ok {
+			delete(s.numbers, number)
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		s.methodNotAllowed(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, render(it, twilio.TimeLayout))
+}
+
+// updateFields returns an update function that copies the given parameters
+// to fields.
+func updateFields(params map[string]string) func(s *Server, it *item, r *http.Request) *apiError {
+	return func(s *Server, it *item, r *http.Request) *apiError {
+		for param, field := range params {
+			if vals, ok := r.PostForm[param]; ok {
+				it.fields[field] = vals[0]
+			}
+		}
+		return nil
+	}
+}
+
+// segments returns the number of SMS segments needed to send body, assuming
+// it only contains GSM characters.
+func segments(body string) int {
+	n := len([]rune(body))
+	if n <= 160 {
+		return 1
+	}
+	return (n + 152) / 153
+}
+
+func (s *Server) createMessage(acct *account, r *http.Request) (*item, *apiError) {
+	to := r.PostForm.Get("To")
+	from := r.PostForm.Get("From")
+	serviceSid := r.PostForm.Get("MessagingServiceSid")
+	body := r.PostForm.Get("Body")
+	media := r.PostForm["MediaUrl"]
+	switch {
+	case to == "":
+		return nil, badRequest(twilio.CodeMessageToRequired)
+	case from == "" && serviceSid == "":
+		return nil, badRequest(twilio.CodeMessageFromRequired)
+	case body == "" && len(media) == 0:
+		return nil, badRequest(twilio.CodeBodyOrMediaRequired)
+	case len([]rune(body)) > 1600:
+		return nil, badRequest(twilio.CodeMessageBodyTooLong)
+	case len(media) > 10:
+		return nil, badRequest(twilio.CodeTooManyMediaFiles)
+	}
+	switch to {
+	case InvalidNumber:
+		return nil, badRequest(twilio.CodeInvalidToNumber)
+	case UnroutableNumber:
+		return nil, badRequest(twilio.CodeToNotReachableBySMS)
+	case UnsubscribedNumber:
+		return nil, badRequest(twilio.CodeUnsubscribedRecipient)
+	}
+	prefix := "SM"
+	if len(media) > 0 {
+		prefix = "MM"
+	}
+	it := s.newItem(prefix)
+	status := twilio.StatusQueued
+	var service interface{}
+	if serviceSid != "" {
+		service = serviceSid
+		if from == "" {
+			status = twilio.StatusAccepted
+		}
+	}
+	u := uri(acct, "Messages", it.sid)
+	for k, v := range map[string]interface{}{
+		"account_sid":           acct.sid,
+		"api_version":           twilio.APIVersion,
+		"body":                  body,
+		"to":                    to,
+		"from":                  from,
+		"status":                status,
+		"messaging_service_sid": service,
+		"direction":             "outbound-api",
+		"date_sent":             it.created,
+		"num_segments":          strconv.Itoa(segments(body)),
+		"num_media":             strconv.Itoa(len(media)),
+		"price":                 nil,
+		"price_unit":            "USD",
+		"error_code":            nil,
+		"error_message":         nil,
+		"uri":                   u,
+		"subresource_uris": map[string]string{
+			"media": strings.TrimSuffix(u, ".json") + "/Media.json",
+		},
+	} {
+		it.fields[k] = v
+	}
+	return it, nil
+}
+
+// updateMessage redacts the body of a message. Twilio doesn't allow any other
+// changes.
+func (s *Server) updateMessage(it *item, r *http.Request) *apiError {
+	vals, ok := r.PostForm["Body"]
+	if !ok || vals[0] != "" {
+		return &apiError{http.StatusBadRequest, twilio.CodeUnknownParameters, "Body can only be set to the empty string"}
+	}
+	switch it.fields["status"] {
+	case twilio.StatusAccepted, twilio.StatusQueued, twilio.StatusSending:
+		return &apiError{http.StatusBadRequest, twilio.CodeUnknownParameters, "Cannot redact a message that is being sent"}
+	}
+	it.fields["body"] = ""
+	return nil
+}
+
+func (s *Server) advanceMessage(it *item) {
+	switch it.fields["status"] {
+	case twilio.StatusAccepted:
+		it.fields["status"] = twilio.StatusQueued
+	case twilio.StatusQueued:
+		it.fields["status"] = twilio.StatusSending
+	case twilio.StatusSending:
+		it.fields["status"] = twilio.StatusSent
+		segs, _ := strconv.Atoi(it.fields["num_segments"].(string))
+		it.fields["price"] = fmt.Sprintf("-%.5f", 0.0075*float64(segs))
+	case twilio.StatusSent:
+		if it.fields["to"] == UnreachableNumber {
+			it.fields["status"] = twilio.StatusUndelivered
+			it.fields["error_code"] = twilio.CodeUnreachable
+			it.fields["error_message"] = twilio.Code(twilio.CodeUnreachable).Summary()
+		} else {
+			it.fields["status"] = twilio.StatusDelivered
+		}
+	default:
+		return
+	}
+	s.touch(it)
+}
+
+func (s *Server) createCall(acct *account, r *http.Request) (*item, *apiError) {
+	to := r.PostForm.Get("To")
+	from := r.PostForm.Get("From")
+	switch {
+	case to == "":
+		return nil, badRequest(twilio.CodeNoToNumber)
+	case from == "":
+		return nil, badRequest(twilio.CodeFromNumberRequired)
+	case r.PostForm.Get("Url") == "" && r.PostForm.Get("ApplicationSid") == "":
+		return nil, &apiError{http.StatusBadRequest, twilio.CodeInvalidURL, "Url parameter is required"}
+	case to == InvalidNumber:
+		return nil, badRequest(twilio.CodeInvalidToNumber)
+	}
+	it := s.newItem("CA")
+	u := uri(acct, "Calls", it.sid)
+	for k, v := range map[string]interface{}{
+		"account_sid":      acct.sid,
+		"api_version":      twilio.APIVersion,
+		"to":               to,
+		"from":             from,
+		"status":           twilio.StatusQueued,
+		"direction":        "outbound-api",
+		"start_time":       it.created,
+		"end_time":         time.Time{},
+		"duration":         nil,
+		"price":            nil,
+		"price_unit":       "USD",
+		"answered_by":      nil,
+		"caller_name":      nil,
+		"annotation":       nil,
+		"forwarded_from":   nil,
+		"group_sid":        nil,
+		"parent_call_sid":  nil,
+		"phone_number_sid": "",
+		"uri":              u,
+		"subresource_uris": map[string]string{
+			"recordings": strings.TrimSuffix(u, ".json") + "/Recordings.json",
+		},
+	} {
+		it.fields[k] = v
+	}
+	return it, nil
+}
+
+func (s *Server) endCall(it *item, status twilio.Status) {
+	now := s.Now().UTC().Truncate(time.Second)
+	it.fields["status"] = status
+	it.fields["end_time"] = now
+	if status != twilio.StatusCompleted {
+		it.fields["duration"] = "0"
+		return
+	}
+	start := it.fields["start_time"].(time.Time)
+	secs := int(now.Sub(start) / time.Second)
+	it.fields["duration"] = strconv.Itoa(secs)
+	it.fields["price"] = fmt.Sprintf("-%.5f", 0.013*float64(secs/60+1))
+}
+
+func (s *Server) advanceCall(it *item) {
+	switch it.fields["status"] {
+	case twilio.StatusQueued:
+		it.fields["status"] = twilio.StatusRinging
+	case twilio.StatusRinging:
+		if it.fields["to"] == UnreachableNumber {
+			s.endCall(it, twilio.StatusNoAnswer)
+		} else {
+			it.fields["status"] = twilio.StatusInProgress
+		}
+	case twilio.StatusInProgress:
+		s.endCall(it, twilio.StatusCompleted)
+	default:
+		return
+	}
+	s.touch(it)
+}
+
+func (s *Server) updateCall(it *item, r *http.Request) *apiError {
+	status := it.fields["status"]
+	ended := status != twilio.StatusQueued && status != twilio.StatusRinging && status != twilio.StatusInProgress
+	if ended {
+		return &apiError{http.StatusBadRequest, twilio.CodeInvalidCallState, "Call is not in-progress. Cannot redirect."}
+	}
+	switch twilio.Status(r.PostForm.Get("Status")) {
+	case "":
+	case twilio.StatusCanceled:
+		if status == twilio.StatusInProgress {
+			return badRequest(twilio.CodeInvalidCallState)
+		}
+		s.endCall(it, twilio.StatusCanceled)
+	case twilio.StatusCompleted:
+		if status == twilio.StatusInProgress {
+			s.endCall(it, twilio.StatusCompleted)
+		} else {
+			s.endCall(it, twilio.StatusCanceled)
+		}
+	default:
+		return &apiError{http.StatusBadRequest, twilio.CodeUnknownParameters, "Status must be canceled or completed"}
+	}
+	return nil
+}
+
+func (s *Server) serveNumbers(w http.ResponseWriter, r *http.Request, acct *account, res *resource, coll *collection, numberType string) {
+	if r.Method == "GET" {
+		s.serveList(w, r, acct, res, coll, map[string]string{"number_type": numberType})
+		return
+	}
+	if r.Method != "POST" {
+		s.methodNotAllowed(w, r)
+		return
+	}
+	it, err := s.createNumber(acct, r, numberType)
+	if err != nil {
+		s.writeAPIError(w, err)
+		return
+	}
+	coll.add(it)
+	writeJSON(w, http.StatusCreated, render(it, twilio.TimeLayout))
+}
+
+var tollFreePrefixes = []string{"+1800", "+1833", "+1844", "+1855", "+1866", "+1877", "+1888"}
+
+func validNumber(number string) bool {
+	if len(number) < 8 || number[0] != '+' {
+		return false
+	}
+	for _, r := range number[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) createNumber(acct *account, r *http.Request, numberType string) (*item, *apiError) {
+	number := r.PostForm.Get("PhoneNumber")
+	if number == "" {
+		areaCode := r.PostForm.Get("AreaCode")
+		if areaCode == "" && numberType == "TollFree" {
+			areaCode = "800"
+		}
+		if len(areaCode) != 3 {
+			return nil, badRequest(twilio.CodeInvalidAreaCode)
+		}
+		number = fmt.Sprintf("+1%s555%04d", areaCode, (s.count+1)%10000)
+	}
+	if !validNumber(number) {
+		return nil, badRequest(twilio.CodePhoneNumberInvalid)
+	}
+	if numberType == "" {
+		numberType = "Local"
+		for _, prefix := range tollFreePrefixes {
+			if strings.HasPrefix(number, prefix) {
+				numberType = "TollFree"
+			}
+		}
+	}
+	if number == UnavailableNumber || s.numbers[number] {
+		return nil, badRequest(twilio.CodePhoneNumberUnavailable)
+	}
+	it := s.newItem("PN")
+	friendlyName := r.PostForm.Get("FriendlyName")
+	if friendlyName == "" {
+		friendlyName = twilio.PhoneNumber(number).Friendly()
+	}
+	for k, v := range map[string]interface{}{
+		"account_sid":            acct.sid,
+		"api_version":            twilio.APIVersion,
+		"phone_number":           number,
+		"friendly_name":          friendlyName,
+		"number_type":            numberType,
+		"address_requirements":   "none",
+		"beta":                   false,
+		"capabilities":           map[string]bool{"mms": true, "sms": true, "voice": true},
+		"emergency_address_sid":  nil,
+		"emergency_status":       "Inactive",
+		"sms_application_sid":    "",
+		"sms_fallback_method":    "POST",
+		"sms_fallback_url":       "",
+		"sms_method":             "POST",
+		"sms_url":                r.PostForm.Get("SmsUrl"),
+		"status_callback":        r.PostForm.Get("StatusCallback"),
+		"status_callback_method": "POST",
+		"trunk_sid":              nil,
+		"voice_application_sid":  "",
+		"voice_caller_id_lookup": false,
+		"voice_fallback_method":  "POST",
+		"voice_fallback_url":     "",
+		"voice_method":           "POST",
+		"voice_url":              r.PostForm.Get("VoiceUrl"),
+		"uri":                    uri(acct, "IncomingPhoneNumbers", it.sid),
+	} {
+		it.fields[k] = v
+	}
+	s.numbers[number] = true
+	return it, nil
+}
+
+func (s *Server) createQueue(acct *account, r *http.Request) (*item, *apiError) {
+	name := r.PostForm.Get("FriendlyName")
+	if name == "" {
+		return nil, &apiError{http.StatusBadRequest, twilio.CodeInvalidFriendlyName, "FriendlyName is required"}
+	}
+	it := s.newItem("QU")
+	it.fields["account_sid"] = acct.sid
+	it.fields["friendly_name"] = name
+	it.fields["average_wait_time"] = 0
+	it.fields["current_size"] = 0
+	it.fields["max_size"] = 100
+	it.fields["uri"] = uri(acct, "Queues", it.sid)
+	return it, s.updateQueue(it, r)
+}
+
+func (s *Server) updateQueue(it *item, r *http.Request) *apiError {
+	if name := r.PostForm.Get("FriendlyName"); name != "" {
+		it.fields["friendly_name"] = name
+	}
+	if size := r.PostForm.Get("MaxSize"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil || n < 1 || n > 5000 {
+			return &apiError{http.StatusBadRequest, twilio.CodeUnknownParameters, "MaxSize must be between 1 and 5000"}
+		}
+		it.fields["max_size"] = n
+	}
+	return nil
+}
+
+func (s *Server) updateConference(it *item, r *http.Request) *apiError {
+	switch twilio.Status(r.PostForm.Get("Status")) {
+	case "":
+		return nil
+	case twilio.StatusCompleted:
+		it.fields["status"] = twilio.StatusCompleted
+		return nil
+	default:
+		return &apiError{http.StatusBadRequest, twilio.CodeUnknownParameters, "Status must be completed"}
+	}
+}
+
+// newAccount creates an account. owner is empty for the main account.
+func (s *Server) newAccount(friendlyName string, owner string) *account {
+	it := s.newItem("AC")
+	acct := &account{
+		item:      it,
+		token:     fmt.Sprintf("%032x", s.count*7919),
+		resources: make(map[string]*collection),
+	}
+	if owner == "" {
+		owner = it.sid
+	}
+	u := "/" + twilio.APIVersion + "/Accounts/" + it.sid
+	subresources := make(map[string]string)
+	for name := range resources {
+		subresources[strings.ToLower(name)] = u + "/" + name + ".json"
+	}
+	for k, v := range map[string]interface{}{
+		"friendly_name":     friendlyName,
+		"type":              "Full",
+		"auth_token":        acct.token,
+		"owner_account_sid": owner,
+		"status":            twilio.StatusActive,
+		"uri":               u + ".json",
+		"subresource_uris":  subresources,
+	} {
+		it.fields[k] = v
+	}
+	s.accounts[it.sid] = acct
+	s.accountOrder = append(s.accountOrder, it.sid)
+	return acct
+}
+
+var accountFilters = map[string]string{"FriendlyName": "friendly_name", "Status": "status"}
+
+func (s *Server) serveAccounts(w http.ResponseWriter, r *http.Request, authed *account) {
+	switch r.Method {
+	case "GET":
+		var items []*item
+		for i := len(s.accountOrder) - 1; i >= 0; i-- {
+			acct := s.accounts[s.accountOrder[i]]
+			if canAccess(authed, acct) {
+				items = append(items, acct.item)
+			}
+		}
+		s.writePage(w, r, "accounts", filter(items, r, accountFilters))
+	case "POST":
+		name := r.PostForm.Get("FriendlyName")
+		if name == "" {
+			name = "SubAccount Created at " + s.Now().UTC().Format("2006-01-02 03:04 pm")
+		}
+		acct := s.newAccount(name, authed.sid)
+		writeJSON(w, http.StatusCreated, render(acct.item, twilio.TimeLayout))
+	default:
+		s.methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) serveAccount(w http.ResponseWriter, r *http.Request, authed *account, sid string) {
+	acct, ok := s.accounts[sid]
+	if !ok || !canAccess(authed, acct) {
+		s.notFound(w, r)
+		return
+	}
+	switch r.Method {
+	case "GET":
+	case "POST":
+		if name := r.PostForm.Get("FriendlyName"); name != "" {
+			acct.fields["friendly_name"] = name
+		}
+		switch status := twilio.Status(r.PostForm.Get("Status")); status {
+		case "":
+		case twilio.StatusActive, twilio.StatusSuspended, twilio.StatusClosed:
+			acct.fields["status"] = status
+		default:
+			s.writeError(w, http.StatusBadRequest, twilio.CodeUnknownParameters, "Invalid Status: "+string(status))
+			return
+		}
+		s.touch(acct.item)
+	default:
+		s.methodNotAllowed(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, render(acct.item, twilio.TimeLayout))
+}
+
+// AddRecording adds a completed Recording of the given length for the Call
+// with the given sid to the main account, and returns the Recording's sid.
+func (s *Server) AddRecording(callSid string, duration time.Duration) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	acct := s.accounts[s.AccountSid]
+	it := s.newItem("RE")
+	for k, v := range map[string]interface{}{
+		"account_sid": acct.sid,
+		"api_version": twilio.APIVersion,
+		"call_sid":    callSid,
+		"duration":    strconv.Itoa(int(duration / time.Second)),
+		"status":      "completed",
+		"channels":    1,
+		"price":       "-0.00250",
+		"price_unit":  "USD",
+		"uri":         uri(acct, "Recordings", it.sid),
+	} {
+		it.fields[k] = v
+	}
+	acct.collection("Recordings").add(it)
+	return it.sid
+}
+
+// serveRecordingAudio serves the audio for a Recording, as silence of the
+// Recording's duration. WAV files are 8 kHz, 8-bit mono; MP3 files are
+// placeholders of the same size. Range requests are supported.
+func (s *Server) serveRecordingAudio(w http.ResponseWriter, r *http.Request, coll *collection, name string) {
+	ext := name[strings.LastIndex(name, "."):]
+	it, ok := coll.items[strings.TrimSuffix(name, ext)]
+	if !ok {
+		s.notFound(w, r)
+		return
+	}
+	if r.Method != "GET" && r.Method != "HEAD" {
+		s.methodNotAllowed(w, r)
+		return
+	}
+	seconds, _ := strconv.Atoi(fmt.Sprint(it.fields["duration"]))
+	const rate = 8000
+	size := seconds * rate
+	var buf bytes.Buffer
+	if ext == ".wav" {
+		w.Header().Set("Content-Type", "audio/x-wav")
+		buf.WriteString("RIFF")
+		binary.Write(&buf, binary.LittleEndian, uint32(36+size))
+		buf.WriteString("WAVEfmt ")
+		// PCM, one channel, rate samples and bytes per second, one byte
+		// per sample
+		for _, v := range []interface{}{uint32(16), uint16(1), uint16(1), uint32(rate), uint32(rate), uint16(1), uint16(8)} {
+			binary.Write(&buf, binary.LittleEndian, v)
+		}
+		buf.WriteString("data")
+		binary.Write(&buf, binary.LittleEndian, uint32(size))
+		buf.Write(bytes.Repeat([]byte{0x80}, size))
+	} else {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		buf.WriteString("ID3")
+		buf.Write(make([]byte, size))
+	}
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(buf.Bytes()))
+}
+
+// AddTranscription adds a Transcription of the Recording with the given sid
+// to the main account, and returns the Transcription's sid.
+func (s *Server) AddTranscription(recordingSid string, text string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	acct := s.accounts[s.AccountSid]
+	it := s.newItem("TR")
+	var duration interface{}
+	if rec, ok := acct.collection("Recordings").items[recordingSid]; ok {
+		duration = rec.fields["duration"]
+	}
+	for k, v := range map[string]interface{}{
+		"account_sid":        acct.sid,
+		"api_version":        twilio.APIVersion,
+		"recording_sid":      recordingSid,
+		"transcription_text": text,
+		"status":             "completed",
+		"duration":           duration,
+		"price":              "-0.05000",
+		"price_unit":         "USD",
+		"type":               "fast",
+		"uri":                uri(acct, "Transcriptions", it.sid),
+	} {
+		it.fields[k] = v
+	}
+	acct.collection("Transcriptions").add(it)
+	return it.sid
+}
+
+// AddConference adds an in-progress Conference with the given name to the
+// main account, and returns its sid.
+func (s *Server) AddConference(friendlyName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	acct := s.accounts[s.AccountSid]
+	it := s.newItem("CF")
+	it.fields["account_sid"] = acct.sid
+	it.fields["api_version"] = twilio.APIVersion
+	it.fields["friendly_name"] = friendlyName
+	it.fields["status"] = twilio.StatusInProgress
+	it.fields["region"] = "us1"
+	it.fields["uri"] = uri(acct, "Conferences", it.sid)
+	acct.collection("Conferences").add(it)
+	return it.sid
+}
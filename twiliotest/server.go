@@ -0,0 +1,516 @@
+// Package twiliotest runs an in-process fake of the Twilio API, so you can
+// test code that uses twilio-go end to end without making network requests.
+//
+//	s := twiliotest.NewServer()
+//	defer s.Close()
+//	client := s.Client()
+//	msg, err := client.Messages.SendMessageContext(ctx, from, to, "hi", nil)
+//
+// The fake keeps resources in memory and supports Messages, Calls,
+// IncomingPhoneNumbers, Recordings, Transcriptions, Queues, Conferences and
+// Accounts on api.twilio.com, Alerts on monitor.twilio.com, and a small set of
+// prices on pricing.twilio.com. Lists are paged, newest first, with a PageToken
+// like the real API, so deleting resources while paging through them works the
+// same way. Errors are returned in Twilio's JSON format.
+//
+// Messages and Calls move to the next status every time they are fetched, so
+// a Message goes from "queued" to "sending", "sent" and then "delivered".
+// Sending to one of the magic numbers in this package makes a request fail,
+// or a Message or Call end unsuccessfully.
+package twiliotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	twilio "github.com/saintpete/twilio-go"
+)
+
+// Magic phone numbers. Using them as the To number for a Message or a Call
+// changes how the fake behaves. The first four match the magic numbers
+// Twilio uses for test credentials.
+const (
+	// Creating a Message or a Call to InvalidNumber fails with error 21211.
+	InvalidNumber = "+15005550001"
+	// Creating a Message to UnroutableNumber fails with error 21612.
+	UnroutableNumber = "+15005550002"
+	// Creating a Message to UnsubscribedNumber fails with error 21610.
+	UnsubscribedNumber = "+15005550004"
+	// Buying UnavailableNumber fails with error 21422.
+	UnavailableNumber = "+15005550000"
+	// Messages to UnreachableNumber end up undelivered with error 30003, and
+	// Calls to it end with no-answer.
+	UnreachableNumber = "+15005550011"
+)
+
+// DefaultPageSize is the number of results in a page if the request doesn't
+// specify a PageSize.
+const DefaultPageSize = 50
+
+// A Server is a fake Twilio API. Create one with NewServer. A Server is safe
+// for concurrent use.
+type Server struct {
+	// URL is the base URL of the fake, for example "http://127.0.0.1:5555".
+	// It serves the API, Monitor and Pricing resources.
+	URL string
+	// The credentials for the main account. Requests must use them, or the
+	// credentials of a subaccount, for Basic Auth.
+	AccountSid string
+	AuthToken  string
+	// Now returns the current time, and is used to timestamp new resources.
+	// Replace it before making requests to control the timestamps.
+	Now func() time.Time
+
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	count    int
+	accounts map[string]*account
+	// main account first, then subaccounts in the order they were created.
+	accountOrder []string
+	alerts       *collection
+	numbers      map[string]bool
+}
+
+type account struct {
+	*item
+	token     string
+	resources map[string]*collection
+}
+
+// An item is a single resource. Fields are rendered as JSON; time.Time values
+// are formatted the way the API formats timestamps.
+type item struct {
+	sid     string
+	created time.Time
+	fields  map[string]interface{}
+}
+
+type collection struct {
+	items map[string]*item
+	order []*item
+}
+
+func newCollection() *collection {
+	return &collection{items: make(map[string]*item)}
+}
+
+func (c *collection) add(it *item) {
+	c.items[it.sid] = it
+	c.order = append(c.order, it)
+}
+
+func (c *collection) remove(sid string) bool {
+	if _, ok := c.items[sid]; !ok {
+		return false
+	}
+	delete(c.items, sid)
+	for i, it := range c.order {
+		if it.sid == sid {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// newest returns the items in c, newest first.
+func (c *collection) newest() []*item {
+	items := make([]*item, len(c.order))
+	for i, it := range c.order {
+		items[len(items)-1-i] = it
+	}
+	sort.Stable(byCreatedDesc(items))
+	return items
+}
+
+type byCreatedDesc []*item
+
+func (b byCreatedDesc) Len() int           { return len(b) }
+func (b byCreatedDesc) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byCreatedDesc) Less(i, j int) bool { return b[i].created.After(b[j].created) }
+
+// NewServer starts and returns a new Server with a single, active account. The
+// caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		Now:      time.Now,
+		accounts: make(map[string]*account),
+		alerts:   newCollection(),
+		numbers:  make(map[string]bool),
+	}
+	main := s.newAccount("Main Account", "")
+	s.AccountSid = main.sid
+	s.AuthToken = main.token
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a twilio.Client that makes requests to s, using the main
+// account's credentials.
+func (s *Server) Client() *twilio.Client {
+	c := twilio.NewClient(s.AccountSid, s.AuthToken, nil)
+	s.configure(c)
+	return c
+}
+
+// configure points c and its Monitor and Pricing clients at s.
+func (s *Server) configure(c *twilio.Client) {
+	c.Base = s.URL
+	c.Monitor.Base = s.URL
+	c.Pricing.Base = s.URL
+}
+
+// newSid returns a new, unique sid with the given prefix. Callers must hold
+// s.mu, except in NewServer.
+func (s *Server) newSid(prefix string) string {
+	s.count++
+	return fmt.Sprintf("%s%032x", prefix, s.count)
+}
+
+func (s *Server) newItem(prefix string) *item {
+	now := s.Now().UTC().Truncate(time.Second)
+	return &item{
+		sid:     s.newSid(prefix),
+		created: now,
+		fields: map[string]interface{}{
+			"date_created": now,
+			"date_updated": now,
+		},
+	}
+}
+
+// touch sets the date_updated field of it.
+func (s *Server) touch(it *item) {
+	it.fields["date_updated"] = s.Now().UTC().Truncate(time.Second)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := r.ParseForm(); err != nil {
+		s.writeError(w, http.StatusBadRequest, twilio.CodeUnknownParameters, err.Error())
+		return
+	}
+	user, pass, _ := r.BasicAuth()
+	acct, ok := s.accounts[user]
+	if !ok || acct.token != pass || acct.fields["status"] == twilio.StatusClosed {
+		s.writeError(w, http.StatusUnauthorized, twilio.CodePermissionDenied, "Authenticate")
+		return
+	}
+	path := r.URL.Path
+	switch {
+	case strings.HasPrefix(path, "/"+twilio.APIVersion+"/"):
+		s.serveAPI(w, r, acct, strings.TrimPrefix(path, "/"+twilio.APIVersion+"/"))
+	case path == "/v1/Alerts" || strings.HasPrefix(path, "/v1/Alerts/"):
+		s.serveAlerts(w, r, acct)
+	case strings.HasPrefix(path, "/v1/"):
+		s.servePricing(w, r)
+	default:
+		s.notFound(w, r)
+	}
+}
+
+// apiError is an error response in Twilio's format.
+type apiError struct {
+	status  int
+	code    twilio.Code
+	message string
+}
+
+func badRequest(code twilio.Code) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: code, message: code.Summary()}
+}
+
+func (s *Server) writeError(w http.ResponseWriter, status int, code twilio.Code, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"code":      code,
+		"message":   message,
+		"more_info": code.URL(),
+		"status":    status,
+	})
+}
+
+func (s *Server) writeAPIError(w http.ResponseWriter, err *apiError) {
+	s.writeError(w, err.status, err.code, err.message)
+}
+
+func (s *Server) notFound(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, http.StatusNotFound, twilio.CodeNotFound, "The requested resource "+r.URL.Path+" was not found")
+}
+
+func (s *Server) methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	s.writeError(w, http.StatusMethodNotAllowed, twilio.CodeMethodNotAllowed, "Method not allowed")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// render returns the JSON representation of it. Timestamps are formatted
+// with layout.
+func render(it *item, layout string) map[string]interface{} {
+	m := make(map[string]interface{}, len(it.fields)+1)
+	for k, v := range it.fields {
+		if t, ok := v.(time.Time); ok {
+			if t.IsZero() {
+				v = nil
+			} else {
+				v = t.Format(layout)
+			}
+		}
+		m[k] = v
+	}
+	m["sid"] = it.sid
+	return m
+}
+
+// pageParams returns the page number and page size requested in r.
+func pageParams(r *http.Request) (page int, size int) {
+	size = DefaultPageSize
+	if n, err := strconv.Atoi(r.Form.Get("PageSize")); err == nil && n > 0 {
+		size = n
+		if size > 1000 {
+			size = 1000
+		}
+	}
+	if n, err := strconv.Atoi(r.Form.Get("Page")); err == nil && n > 0 {
+		page = n
+	}
+	return page, size
+}
+
+// pageURI returns path with the query from r, for the given page. If token is
+// set, it's used as the PageToken.
+func pageURI(r *http.Request, path string, page int, size int, token string) string {
+	q := url.Values{}
+	for k, v := range r.URL.Query() {
+		q[k] = v
+	}
+	q.Del("PageToken")
+	if token != "" {
+		q.Set("PageToken", token)
+	}
+	q.Set("Page", strconv.Itoa(page))
+	q.Set("PageSize", strconv.Itoa(size))
+	return path + "?" + q.Encode()
+}
+
+// pageToken returns a PageToken for the position just after ("PA") or just
+// before ("PB") it in a list. Like Twilio's, the next and previous pages are
+// found from the token, not the page number, so deleting items that were
+// already listed doesn't shift the pages after them. The token holds the
+// item's creation time as well as its sid, so the position can still be found
+// after the item is deleted.
+func pageToken(prefix string, it *item) string {
+	return fmt.Sprintf("%s%s-%d", prefix, it.sid, it.created.Unix())
+}
+
+// listedAfter reports whether it comes after the item with the given sid and
+// creation time in a list. Lists are newest first, and items created in the
+// same second are listed in reverse sid order.
+func listedAfter(it *item, sid string, created time.Time) bool {
+	if !it.created.Equal(created) {
+		return it.created.Before(created)
+	}
+	return it.sid < sid
+}
+
+// tokenIndex returns the index in items of the position marked by token, a
+// token returned by pageToken. For a "PA" token that's the first item after
+// the token's item, and for a "PB" token it's the token's item, or the item
+// that replaced it.
+func tokenIndex(items []*item, token string) (int, bool) {
+	i := strings.LastIndex(token, "-")
+	if len(token) < 3 || (token[:2] != "PA" && token[:2] != "PB") || i < 2 {
+		return 0, false
+	}
+	sec, err := strconv.ParseInt(token[i+1:], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	sid, created := token[2:i], time.Unix(sec, 0)
+	for idx, it := range items {
+		if it.sid == sid && token[:2] == "PA" {
+			return idx + 1, true
+		}
+		if it.sid == sid || listedAfter(it, sid, created) {
+			return idx, true
+		}
+	}
+	return len(items), true
+}
+
+// writePage writes a page of items in the api.twilio.com format, under the
+// given key.
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, key string, items []*item) {
+	page, size := pageParams(r)
+	start := page * size
+	if token := r.Form.Get("PageToken"); token != "" {
+		i, ok := tokenIndex(items, token)
+		if !ok {
+			s.writeAPIError(w, &apiError{http.StatusBadRequest, twilio.CodeUnknownParameters, "Invalid PageToken"})
+			return
+		}
+		start = i
+		if token[:2] == "PB" {
+			start = i - size
+			if start < 0 {
+				start = 0
+			}
+		}
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+	pageItems := items[start:end]
+	rendered := make([]map[string]interface{}, len(pageItems))
+	for i, it := range pageItems {
+		rendered[i] = render(it, twilio.TimeLayout)
+	}
+	path := r.URL.Path
+	resp := map[string]interface{}{
+		key:                 rendered,
+		"first_page_uri":    pageURI(r, path, 0, size, ""),
+		"next_page_uri":     nil,
+		"previous_page_uri": nil,
+		"page":              page,
+		"page_size":         size,
+		"start":             start,
+		"end":               end - 1,
+		"uri":               r.URL.RequestURI(),
+	}
+	if len(pageItems) == 0 {
+		resp["end"] = start
+	}
+	if end < len(items) {
+		resp["next_page_uri"] = pageURI(r, path, page+1, size, pageToken("PA", items[end-1]))
+	}
+	if start > 0 && page > 0 {
+		uri := pageURI(r, path, page-1, size, "")
+		if start < len(items) {
+			uri = pageURI(r, path, page-1, size, pageToken("PB", items[start]))
+		}
+		resp["previous_page_uri"] = uri
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// writeMetaPage writes a page of items in the format used by the Monitor and
+// Pricing APIs, under the given key.
+func (s *Server) writeMetaPage(w http.ResponseWriter, r *http.Request, key string, items []map[string]interface{}) {
+	page, size := pageParams(r)
+	start := page * size
+	end := start + size
+	more := end < len(items)
+	if start > len(items) {
+		start = len(items)
+	}
+	if end > len(items) {
+		end = len(items)
+	}
+	path := r.URL.Path
+	meta := map[string]interface{}{
+		"first_page_url":    s.URL + pageURI(r, path, 0, size, ""),
+		"next_page_url":     nil,
+		"previous_page_url": nil,
+		"key":               key,
+		"page":              page,
+		"page_size":         size,
+		"url":               s.URL + r.URL.RequestURI(),
+	}
+	if more {
+		meta["next_page_url"] = s.URL + pageURI(r, path, page+1, size, "")
+	}
+	if page > 0 {
+		meta["previous_page_url"] = s.URL + pageURI(r, path, page-1, size, "")
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"meta": meta,
+		key:    items[start:end],
+	})
+}
+
+// filter returns the items whose fields match the equality filters in r.
+// params maps a query parameter, like "To", to the field it filters, like
+// "to".
+func filter(items []*item, r *http.Request, params map[string]string) []*item {
+	var filtered []*item
+outer:
+	for _, it := range items {
+		for param, field := range params {
+			want := r.Form.Get(param)
+			if want == "" {
+				continue
+			}
+			if fmt.Sprint(it.fields[field]) != want {
+				continue outer
+			}
+		}
+		filtered = append(filtered, it)
+	}
+	return filtered
+}
+
+// filterDates returns the items whose field matches the date filters in r.
+// "Param" matches the given day, "Param>" matches dates on or after the given
+// day, and "Param<" matches dates on or before midnight at the start of the
+// given day. Days are formatted like twilio.APISearchLayout.
+func filterDates(items []*item, r *http.Request, param string, field string) ([]*item, *apiError) {
+	var on, after, before time.Time
+	for _, f := range []struct {
+		suffix string
+		t      *time.Time
+	}{{"", &on}, {">", &after}, {"<", &before}} {
+		val := r.Form.Get(param + f.suffix)
+		if val == "" {
+			continue
+		}
+		t, err := time.Parse(twilio.APISearchLayout, val)
+		if err != nil {
+			return nil, &apiError{http.StatusBadRequest, twilio.CodeUnknownParameters, "Invalid " + param + ": " + val}
+		}
+		*f.t = t
+	}
+	if on.IsZero() && after.IsZero() && before.IsZero() {
+		return items, nil
+	}
+	var filtered []*item
+	for _, it := range items {
+		t, ok := it.fields[field].(time.Time)
+		if !ok || t.IsZero() {
+			continue
+		}
+		if !on.IsZero() && (t.Before(on) || !t.Before(on.Add(24*time.Hour))) {
+			continue
+		}
+		if !after.IsZero() && t.Before(after) {
+			continue
+		}
+		if !before.IsZero() && t.After(before) {
+			continue
+		}
+		filtered = append(filtered, it)
+	}
+	return filtered, nil
+}
@@ -0,0 +1,624 @@
+package twiliotest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	twilio "github.com/saintpete/twilio-go"
+	"golang.org/x/net/context"
+)
+
+const from = "+19253920364"
+const to = "+19252717005"
+
+var callURL, _ = url.Parse("https://kev.inburke.com/zombo/zombocom.mp3")
+
+func TestSendMessage(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	msg, err := client.Messages.SendMessageContext(ctx, from, to, "hello", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Status != twilio.StatusQueued || msg.To != to || msg.Body != "hello" || msg.NumSegments != 1 {
+		t.Errorf("bad message: %#v", msg)
+	}
+	if !msg.DateCreated.Valid {
+		t.Errorf("expected DateCreated to be set")
+	}
+	want := []twilio.Status{twilio.StatusSending, twilio.StatusSent, twilio.StatusDelivered, twilio.StatusDelivered}
+	for _, status := range want {
+		msg, err = client.Messages.Get(ctx, msg.Sid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.Status != status {
+			t.Errorf("expected status %s, got %s", status, msg.Status)
+		}
+	}
+	if msg.Price == "" {
+		t.Errorf("expected a price for a sent message")
+	}
+}
+
+func TestUnreachableMessage(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	msg, err := client.Messages.SendMessageContext(ctx, from, UnreachableNumber, "hello", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		msg, err = client.Messages.Get(ctx, msg.Sid)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if msg.Status != twilio.StatusUndelivered || msg.ErrorCode != twilio.CodeUnreachable {
+		t.Errorf("expected message to be undelivered with 30003, got %s %d", msg.Status, msg.ErrorCode)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	_, err := client.Messages.SendMessageContext(ctx, from, InvalidNumber, "hello", nil)
+	terr, ok := err.(*twilio.Error)
+	if !ok {
+		t.Fatalf("expected a *twilio.Error, got %#v", err)
+	}
+	if terr.Code != twilio.CodeInvalidToNumber || terr.StatusCode != 400 {
+		t.Errorf("bad error: %#v", terr)
+	}
+	if terr.MoreInfo != "https://www.twilio.com/docs/errors/21211" {
+		t.Errorf("bad MoreInfo: %s", terr.MoreInfo)
+	}
+	_, err = client.Messages.SendMessageContext(ctx, from, to, "", nil)
+	if terr, ok := err.(*twilio.Error); !ok || terr.Code != twilio.CodeBodyOrMediaRequired {
+		t.Errorf("expected 21619 error, got %v", err)
+	}
+	_, err = client.Calls.Get(ctx, "CA123")
+	if !twilio.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	bad := twilio.NewClient(s.AccountSid, "wrong", nil)
+	s.configure(bad)
+	_, err = bad.Calls.Get(ctx, "CA123")
+	if terr, ok := err.(*twilio.Error); !ok || terr.StatusCode != 401 || terr.Code != twilio.CodePermissionDenied {
+		t.Errorf("expected 401 error, got %v", err)
+	}
+}
+
+func TestPaging(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		if _, err := client.Calls.MakeCallContext(ctx, from, to, callURL); err != nil {
+			t.Fatal(err)
+		}
+	}
+	iter := client.Calls.GetPageIterator(url.Values{"PageSize": []string{"2"}})
+	var sids []string
+	for {
+		page, err := iter.Next(ctx)
+		if err == twilio.NoMoreResults {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, call := range page.Calls {
+			sids = append(sids, call.Sid)
+		}
+	}
+	if len(sids) != 5 {
+		t.Fatalf("expected 5 calls, got %d", len(sids))
+	}
+	// newest first
+	for i := 1; i < len(sids); i++ {
+		if sids[i] > sids[i-1] {
+			t.Errorf("expected calls to be sorted newest first, got %v", sids)
+		}
+	}
+}
+
+// Pages are found by PageToken, so deleting the resources on a page doesn't
+// shift the pages after it.
+func TestDeleteWhilePaging(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		s.AddRecording("CA123", time.Second)
+	}
+	iter := client.Recordings.GetPageIterator(url.Values{"PageSize": []string{"2"}})
+	seen := 0
+	for {
+		page, err := iter.Next(ctx)
+		if err == twilio.NoMoreResults {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, rec := range page.Recordings {
+			if err := client.Recordings.Delete(ctx, rec.Sid); err != nil {
+				t.Fatal(err)
+			}
+			seen++
+		}
+	}
+	if seen != 5 {
+		t.Errorf("expected to page through 5 recordings, got %d", seen)
+	}
+	page, err := client.Recordings.GetPage(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Recordings) != 0 {
+		t.Errorf("expected every recording to be deleted, got %v", page.Recordings)
+	}
+}
+
+func TestCallStatus(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	call, err := client.Calls.MakeCallContext(ctx, from, to, callURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call, err = client.Calls.CancelContext(ctx, call.Sid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Status != twilio.StatusCanceled || !call.EndTime.Valid {
+		t.Errorf("expected call to be canceled, got %s", call.Status)
+	}
+	_, err = client.Calls.HangupContext(ctx, call.Sid)
+	if terr, ok := err.(*twilio.Error); !ok || terr.Code != twilio.CodeInvalidCallState {
+		t.Errorf("expected 21220 hanging up a canceled call, got %v", err)
+	}
+}
+
+func TestDateFilters(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	now := time.Date(2016, 11, 1, 12, 0, 0, 0, time.UTC)
+	s.Now = func() time.Time { return now }
+	client := s.Client()
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Messages.SendMessageContext(ctx, from, to, "hello", nil); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(24 * time.Hour)
+	}
+	iter := client.Messages.GetMessagesInRange(time.Date(2016, 11, 2, 0, 0, 0, 0, time.UTC), time.Date(2016, 11, 3, 0, 0, 0, 0, time.UTC), nil)
+	page, err := iter.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Messages) != 1 {
+		t.Fatalf("expected 1 message in range, got %d", len(page.Messages))
+	}
+	if d := page.Messages[0].DateSent.Time; !d.Equal(time.Date(2016, 11, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("wrong message returned: %v", d)
+	}
+}
+
+func TestRecordingsInRange(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	now := time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC)
+	s.Now = func() time.Time { return now }
+	client := s.Client()
+	ctx := context.Background()
+	for _, d := range []time.Duration{0, 30 * time.Minute, time.Hour, 2*time.Hour - time.Second, 2 * time.Hour} {
+		now = time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC).Add(d)
+		s.AddTranscription(s.AddRecording("CA123", time.Minute), "hello")
+	}
+	start := time.Date(2016, 11, 1, 10, 15, 0, 0, time.UTC)
+	end := time.Date(2016, 11, 1, 12, 0, 0, 0, time.UTC)
+	iter := client.Recordings.GetRecordingsInRange(start, end, url.Values{"PageSize": []string{"2"}})
+	var dates []time.Time
+	for {
+		page, err := iter.Next(ctx)
+		if err == twilio.NoMoreResults {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, rec := range page.Recordings {
+			dates = append(dates, rec.DateCreated.Time)
+		}
+		// a resumed iterator should stay in the same range
+		iter = client.Recordings.ResumePageIterator(iter.Cursor())
+	}
+	if len(dates) != 3 {
+		t.Fatalf("expected 3 recordings in range, got %v", dates)
+	}
+	if !dates[0].Equal(end.Add(-time.Second)) || !dates[2].Equal(start.Add(15*time.Minute)) {
+		t.Errorf("wrong recordings returned: %v", dates)
+	}
+
+	titer := client.Transcriptions.GetTranscriptionsInRange(start, twilio.HeatDeath, nil)
+	page, err := titer.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Transcriptions) != 4 {
+		t.Errorf("expected 4 transcriptions in range, got %d", len(page.Transcriptions))
+	}
+	if _, err := titer.Next(ctx); err != twilio.NoMoreResults {
+		t.Errorf("expected NoMoreResults, got %v", err)
+	}
+}
+
+func TestNumbersAndQueues(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	number, err := client.IncomingNumbers.BuyNumberContext(ctx, "+14105551234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number.PhoneNumber != "+14105551234" || !number.Capabilities.SMS {
+		t.Errorf("bad number: %#v", number)
+	}
+	if _, err := client.IncomingNumbers.BuyNumberContext(ctx, "+14105551234"); err == nil {
+		t.Errorf("expected buying the same number twice to fail")
+	}
+	if err := client.IncomingNumbers.Release(ctx, number.Sid); err != nil {
+		t.Fatal(err)
+	}
+	q, err := client.Queues.Create(ctx, url.Values{"FriendlyName": []string{"support"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.FriendlyName != "support" || q.MaxSize != 100 {
+		t.Errorf("bad queue: %#v", q)
+	}
+	if err := client.Queues.Delete(ctx, q.Sid); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Queues.Get(ctx, q.Sid); !twilio.IsNotFound(err) {
+		t.Errorf("expected deleted queue to be gone, got %v", err)
+	}
+}
+
+func TestSubaccounts(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	acct, err := client.Accounts.Create(ctx, url.Values{"FriendlyName": []string{"sub"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acct.OwnerAccountSid != s.AccountSid || acct.AuthToken == "" {
+		t.Errorf("bad subaccount: %#v", acct)
+	}
+	sub := client.ForSubaccount(acct.Sid)
+	msg, err := sub.Messages.SendMessageContext(ctx, from, to, "hi", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.AccountSid != acct.Sid {
+		t.Errorf("expected message to belong to the subaccount, got %s", msg.AccountSid)
+	}
+	if _, err := client.Messages.Get(ctx, msg.Sid); !twilio.IsNotFound(err) {
+		t.Errorf("expected message to not exist in the main account, got %v", err)
+	}
+	page, err := client.Accounts.GetPage(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Accounts) != 2 {
+		t.Errorf("expected 2 accounts, got %d", len(page.Accounts))
+	}
+}
+
+func TestRecordingsAndConferences(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	rsid := s.AddRecording("CA123", 30*time.Second)
+	s.AddTranscription(rsid, "hello")
+	rec, err := client.Recordings.Get(ctx, rsid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.CallSid != "CA123" || rec.Duration != twilio.TwilioDuration(30*time.Second) {
+		t.Errorf("bad recording: %#v", rec)
+	}
+	page, err := client.Calls.GetRecordings(ctx, "CA123", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Recordings) != 1 {
+		t.Errorf("expected 1 recording for the call, got %d", len(page.Recordings))
+	}
+	tpage, err := client.Recordings.GetTranscriptions(ctx, rsid, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tpage.Transcriptions) != 1 {
+		t.Errorf("expected 1 transcription, got %d", len(tpage.Transcriptions))
+	}
+	csid := s.AddConference("room")
+	conf, err := client.Conferences.Get(ctx, csid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.FriendlyName != "room" || conf.Status != twilio.StatusInProgress {
+		t.Errorf("bad conference: %#v", conf)
+	}
+}
+
+func TestAlerts(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		s.AddAlert(twilio.CodeHTTPRetrievalFailure, twilio.LogLevelError, "ErrorCode=11200&httpResponse=500")
+	}
+	s.AddAlert(twilio.CodeSchemaValidationWarning, twilio.LogLevelWarning, "ErrorCode=12200")
+	iter := client.Monitor.Alerts.GetPageIterator(url.Values{"PageSize": []string{"2"}, "LogLevel": []string{"error"}})
+	count := 0
+	for {
+		page, err := iter.Next(ctx)
+		if err == twilio.NoMoreResults {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, alert := range page.Alerts {
+			count++
+			if alert.ErrorCode != twilio.CodeHTTPRetrievalFailure {
+				t.Errorf("expected only error alerts, got %d", alert.ErrorCode)
+			}
+			if alert.Description() != "HTTP retrieval failure: status code 500 when fetching TwiML" {
+				t.Errorf("bad description: %s", alert.Description())
+			}
+		}
+	}
+	if count != 3 {
+		t.Errorf("expected 3 alerts, got %d", count)
+	}
+}
+
+func TestPricing(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	price, err := client.Pricing.Messaging.Countries.Get(ctx, "US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price.Country != "United States" || len(price.OutboundSMSPrices) != 1 {
+		t.Errorf("bad price: %#v", price)
+	}
+	np, err := client.Pricing.Voice.Numbers.Get(ctx, "+14105551234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if np.IsoCountry != "US" {
+		t.Errorf("expected US number, got %s", np.IsoCountry)
+	}
+	page, err := client.Pricing.PhoneNumbers.Countries.GetPage(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Countries) != 2 {
+		t.Errorf("expected 2 countries, got %d", len(page.Countries))
+	}
+}
+
+func TestRecordingDownload(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	client := s.Client()
+	ctx := context.Background()
+	sid := s.AddRecording("CA123", 2*time.Second)
+	buf := new(bytes.Buffer)
+	n, err := client.Recordings.DownloadTo(ctx, sid, "wav", buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 44+16000 || !strings.HasPrefix(buf.String(), "RIFF") {
+		t.Errorf("expected a 2 second WAV file, got %d bytes starting with %q", n, buf.String()[:4])
+	}
+	body, err := client.Recordings.DownloadRange(ctx, sid, ".wav", 8, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	part, err := ioutil.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(part) != "WAVE" {
+		t.Errorf("expected WAVE, got %q", part)
+	}
+	_, err = client.Recordings.Download(ctx, "RE123", "mp3")
+	if !twilio.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestSweepRecordings(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	now := time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC)
+	s.Now = func() time.Time { return now }
+	client := s.Client()
+	ctx := context.Background()
+	var sids []string
+	for i := 0; i < 4; i++ {
+		now = time.Date(2016, 11, 1+i, 10, 0, 0, 0, time.UTC)
+		sid := s.AddRecording("CA123", time.Second)
+		s.AddTranscription(sid, "hello")
+		sids = append(sids, sid)
+	}
+	opts := &twilio.RetentionOptions{
+		Before: time.Date(2016, 11, 3, 12, 0, 0, 0, time.UTC),
+		DryRun: true,
+	}
+	report, err := client.Recordings.Sweep(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Matched != 3 || strings.Join(report.Deleted, ",") != strings.Join(sids[:3], ",") {
+		t.Errorf("expected a dry run to list the 3 oldest recordings, got %#v", report)
+	}
+
+	opts.DryRun = false
+	opts.Concurrency = 1
+	var archived []string
+	opts.Archive = func(ctx context.Context, rec *twilio.Recording, transcriptions []*twilio.Transcription, audio io.Reader) error {
+		if rec.Sid == sids[1] {
+			return errors.New("archive is full")
+		}
+		data, err := ioutil.ReadAll(audio)
+		if err != nil {
+			return err
+		}
+		if len(transcriptions) != 1 || len(data) != 44+8000 {
+			t.Errorf("bad archive input: %v, %d bytes of audio", transcriptions, len(data))
+		}
+		archived = append(archived, rec.Sid)
+		return nil
+	}
+	report, err = client.Recordings.Sweep(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(archived, ","); got != sids[0]+","+sids[2] {
+		t.Errorf("expected recordings to be archived oldest first, got %s", got)
+	}
+	if report.Archived != 2 || report.TranscriptionsDeleted != 2 || len(report.Deleted) != 2 {
+		t.Errorf("bad report: %#v", report)
+	}
+	if len(report.Failed) != 1 || report.Failed[0].RecordingSid != sids[1] {
+		t.Errorf("expected one failure, got %v", report.Failed)
+	}
+	page, err := client.Recordings.GetPage(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Recordings) != 2 || page.Recordings[0].Sid != sids[3] || page.Recordings[1].Sid != sids[1] {
+		t.Errorf("expected 2 recordings to be kept, got %v", page.Recordings)
+	}
+	tpage, err := client.Transcriptions.GetPage(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tpage.Transcriptions) != 2 {
+		t.Errorf("expected 2 transcriptions to be kept, got %d", len(tpage.Transcriptions))
+	}
+}
+
+func TestSweepRecordingsInBatches(t *testing.T) {
+	t.Parallel()
+	s := NewServer()
+	defer s.Close()
+	var now time.Time
+	s.Now = func() time.Time { return now }
+	client := s.Client()
+	ctx := context.Background()
+	var sids []string
+	for i := 0; i < 6; i++ {
+		// two recordings a day
+		now = time.Date(2016, 11, 1+i/2, 10+i, 0, 0, 0, time.UTC)
+		sids = append(sids, s.AddRecording("CA123", time.Second))
+	}
+	var swept []string
+	opts := &twilio.RetentionOptions{
+		Before:        time.Date(2016, 12, 1, 0, 0, 0, 0, time.UTC),
+		Filters:       url.Values{"PageSize": []string{"2"}},
+		BatchSize:     2,
+		MaxRecordings: 4,
+		Concurrency:   1,
+		Archive: func(ctx context.Context, rec *twilio.Recording, transcriptions []*twilio.Transcription, audio io.Reader) error {
+			swept = append(swept, rec.Sid)
+			return nil
+		},
+	}
+	report, err := client.Recordings.Sweep(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(swept, ","); got != strings.Join(sids[:4], ",") {
+		t.Errorf("expected the 4 oldest recordings to be swept in order, got %s", got)
+	}
+	if report.Matched != 4 || len(report.Deleted) != 4 {
+		t.Errorf("bad report: %#v", report)
+	}
+
+	opts.MaxRecordings = 0
+	report, err = client.Recordings.Sweep(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Matched != 2 || len(report.Deleted) != 2 {
+		t.Errorf("expected the 2 remaining recordings to be deleted, got %#v", report)
+	}
+
+	// A day with several batches of recordings, deleted while paging.
+	now = time.Date(2016, 11, 10, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		s.AddRecording("CA123", time.Second)
+	}
+	report, err = client.Recordings.Sweep(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Matched != 5 || len(report.Deleted) != 5 {
+		t.Errorf("expected 5 recordings to be deleted, got %#v", report)
+	}
+	page, err := client.Recordings.GetPage(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Recordings) != 0 {
+		t.Errorf("expected every recording to be deleted, got %v", page.Recordings)
+	}
+}
@@ -0,0 +1,150 @@
+package twilio
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// WaitOptions control how MessageService.WaitForStatus and
+// CallService.WaitUntilEnded poll for the status of a resource. A nil
+// *WaitOptions uses the defaults.
+type WaitOptions struct {
+	// Interval is the time to wait after the first poll. The wait doubles
+	// after every subsequent poll, up to MaxInterval. The defaults are 1
+	// second and 30 seconds.
+	Interval    time.Duration
+	MaxInterval time.Duration
+	// OnStatusChange, if set, is called every time the resource's status
+	// changes, including when it's fetched for the first time, when from is
+	// the empty string.
+	OnStatusChange func(from Status, to Status)
+}
+
+const defaultWaitInterval = 1 * time.Second
+const defaultMaxWaitInterval = 30 * time.Second
+
+// A StatusError is returned when a Message or Call ends unsuccessfully, for
+// example because the message was undelivered or the call was busy.
+type StatusError struct {
+	// The sid of the Message or Call.
+	Sid    string
+	Status Status
+	// The error code reported for the Message, or zero. Calls don't report
+	// an error code.
+	Code Code
+	// Message is the error message Twilio reported for the Message, or the
+	// Code's Summary if it didn't report one.
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	if e.Code == 0 {
+		return fmt.Sprintf("twilio: %s ended with status %s", e.Sid, e.Status)
+	}
+	return fmt.Sprintf("twilio: %s ended with status %s: %s (error %d)", e.Sid, e.Status, e.Message, e.Code)
+}
+
+// wait calls get until it reports that the resource has ended, backing off
+// between calls as configured by opts. It returns an error if get does, or
+// if ctx is done first.
+func wait(ctx context.Context, opts *WaitOptions, get func(context.Context) (Status, bool, error)) error {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+	max := opts.MaxInterval
+	if max <= 0 {
+		max = defaultMaxWaitInterval
+	}
+	var last Status
+	for {
+		status, ended, err := get(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				// the request was cancelled; report it the same way as a
+				// cancel between requests.
+				return ctx.Err()
+			}
+			return err
+		}
+		if status != last {
+			if opts.OnStatusChange != nil {
+				opts.OnStatusChange(last, status)
+			}
+			last = status
+		}
+		if ended {
+			return nil
+		}
+		t := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+		interval *= 2
+		if interval > max {
+			interval = max
+		}
+	}
+}
+
+// WaitForStatus polls the Message with the given sid until it reaches a
+// terminal state (see Message.Ended), and returns it. If the message ends
+// unsuccessfully, the Message is returned with a *StatusError containing its
+// ErrorCode. If ctx is done first, the last Message fetched is returned with
+// ctx.Err().
+//
+// Some carriers never report whether a "sent" message was delivered, so ctx
+// should have a deadline.
+func (m *MessageService) WaitForStatus(ctx context.Context, sid string, opts *WaitOptions) (*Message, error) {
+	var msg *Message
+	err := wait(ctx, opts, func(ctx context.Context) (Status, bool, error) {
+		latest, err := m.Get(ctx, sid)
+		if err != nil {
+			return "", false, err
+		}
+		msg = latest
+		return msg.Status, msg.Ended(), nil
+	})
+	if err != nil {
+		return msg, err
+	}
+	if msg.EndedUnsuccessfully() {
+		serr := &StatusError{Sid: msg.Sid, Status: msg.Status, Code: msg.ErrorCode, Message: msg.ErrorMessage}
+		if serr.Message == "" {
+			serr.Message = msg.ErrorCode.Summary()
+		}
+		return msg, serr
+	}
+	return msg, nil
+}
+
+// WaitUntilEnded polls the Call with the given sid until it ends (see
+// Call.Ended), and returns it. If the call ends unsuccessfully, the Call is
+// returned with a *StatusError. If ctx is done first, the last Call fetched
+// is returned with ctx.Err().
+func (c *CallService) WaitUntilEnded(ctx context.Context, sid string, opts *WaitOptions) (*Call, error) {
+	var call *Call
+	err := wait(ctx, opts, func(ctx context.Context) (Status, bool, error) {
+		latest, err := c.Get(ctx, sid)
+		if err != nil {
+			return "", false, err
+		}
+		call = latest
+		return call.Status, call.Ended(), nil
+	})
+	if err != nil {
+		return call, err
+	}
+	if call.EndedUnsuccessfully() {
+		return call, &StatusError{Sid: call.Sid, Status: call.Status}
+	}
+	return call, nil
+}
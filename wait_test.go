@@ -0,0 +1,128 @@
+package twilio
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// statusServer returns a resource with the next of statuses each time it's
+// fetched, and then the last one forever.
+func statusServer(statuses []string, extra string) *httptest.Server {
+	var mu sync.Mutex
+	i := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		status := statuses[i]
+		if i < len(statuses)-1 {
+			i++
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"sid": "SM123", "status": %q%s}`, status, extra)
+	}))
+}
+
+var fastWait = &WaitOptions{Interval: time.Millisecond, MaxInterval: 4 * time.Millisecond}
+
+func TestWaitForStatus(t *testing.T) {
+	t.Parallel()
+	s := statusServer([]string{"queued", "queued", "sending", "sent", "delivered"}, "")
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	var transitions []string
+	opts := *fastWait
+	opts.OnStatusChange = func(from Status, to Status) {
+		transitions = append(transitions, string(from)+"->"+string(to))
+	}
+	msg, err := client.Messages.WaitForStatus(context.Background(), "SM123", &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Status != StatusDelivered {
+		t.Errorf("expected delivered, got %s", msg.Status)
+	}
+	want := []string{"->queued", "queued->sending", "sending->sent", "sent->delivered"}
+	if fmt.Sprint(transitions) != fmt.Sprint(want) {
+		t.Errorf("expected transitions %v, got %v", want, transitions)
+	}
+}
+
+func TestWaitForStatusUndelivered(t *testing.T) {
+	t.Parallel()
+	s := statusServer([]string{"sent", "undelivered"}, `, "error_code": 30003`)
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	msg, err := client.Messages.WaitForStatus(context.Background(), "SM123", fastWait)
+	serr, ok := err.(*StatusError)
+	if !ok {
+		t.Fatalf("expected a *StatusError, got %v", err)
+	}
+	if serr.Code != CodeUnreachable || serr.Status != StatusUndelivered || serr.Message == "" {
+		t.Errorf("bad error: %#v", serr)
+	}
+	if msg == nil || msg.Status != StatusUndelivered {
+		t.Errorf("expected the undelivered message to be returned, got %v", msg)
+	}
+}
+
+func TestWaitUntilEndedTimeout(t *testing.T) {
+	t.Parallel()
+	s := statusServer([]string{"ringing"}, "")
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	call, err := client.Calls.WaitUntilEnded(ctx, "CA123", fastWait)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+	if call == nil || call.Status != StatusRinging {
+		t.Errorf("expected the last call fetched, got %v", call)
+	}
+}
+
+func TestMessageEnded(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		status       Status
+		ended        bool
+		unsuccessful bool
+	}{
+		{StatusQueued, false, false},
+		{StatusSent, false, false},
+		{StatusDelivered, true, false},
+		{StatusReceived, true, false},
+		{StatusUndelivered, true, true},
+		{StatusFailed, true, true},
+	} {
+		m := &Message{Status: tt.status}
+		if m.Ended() != tt.ended || m.EndedUnsuccessfully() != tt.unsuccessful {
+			t.Errorf("%s: expected Ended %t, EndedUnsuccessfully %t", tt.status, tt.ended, tt.unsuccessful)
+		}
+	}
+}
+
+func TestWaitForStatusUnknownCode(t *testing.T) {
+	t.Parallel()
+	s := statusServer([]string{"failed"}, `, "error_code": 39999`)
+	defer s.Close()
+	client := NewClient("AC123", "456", nil)
+	client.Base = s.URL
+	_, err := client.Messages.WaitForStatus(context.Background(), "SM123", fastWait)
+	serr, ok := err.(*StatusError)
+	if !ok {
+		t.Fatalf("expected a *StatusError, got %v", err)
+	}
+	if want := Code(39999).Summary(); serr.Message != want {
+		t.Errorf("expected the message for an unknown code to be %q, got %q", want, serr.Message)
+	}
+}